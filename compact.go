@@ -0,0 +1,207 @@
+package set
+
+import (
+	"hash/maphash"
+	"iter"
+	"unsafe"
+)
+
+// FixedKey is a constraint that permits byte arrays of common key sizes,
+// e.g. [16]byte for UUIDs or [32]byte for SHA-256 hashes.
+type FixedKey interface {
+	~[4]byte | ~[8]byte | ~[12]byte | ~[16]byte | ~[20]byte | ~[32]byte | ~[64]byte
+}
+
+// compactMaxLoad is the maximum load factor of a CompactSet in eighths.
+const compactMaxLoad = 7
+
+// A CompactSet is a set of fixed-size keys, which is optimized for memory usage.
+//
+// Elements are stored in an open addressing hash table with Robin Hood hashing
+// directly in a slice of keys and without any per-element metadata.
+// Compared to a [Set] this reduces the memory overhead for large sets of keys like UUIDs,
+// in particular when the capacity is known up front.
+//
+// The zero value of a CompactSet is an empty set ready to use.
+// CompactSet is not safe for concurrent use.
+type CompactSet[K FixedKey] struct {
+	slots   []K
+	n       int
+	hasZero bool
+	seed    maphash.Seed
+}
+
+// NewCompactSet returns a new empty compact set with space for at least capacity elements.
+func NewCompactSet[K FixedKey](capacity int) *CompactSet[K] {
+	s := &CompactSet[K]{}
+	s.resize(capacity)
+	return s
+}
+
+// Add adds elements v to set s.
+func (s *CompactSet[K]) Add(v ...K) {
+	var zero K
+	for _, k := range v {
+		if k == zero {
+			s.hasZero = true
+			continue
+		}
+		if (s.n+1)*8 > len(s.slots)*compactMaxLoad {
+			s.resize(max(2*s.n, 8))
+		}
+		if s.insert(k) {
+			s.n++
+		}
+	}
+}
+
+// All returns on iterator over all elements of set s.
+//
+// Note that the order of the elements is undefined.
+func (s *CompactSet[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		var zero K
+		if s.hasZero && !yield(zero) {
+			return
+		}
+		for _, k := range s.slots {
+			if k != zero && !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all elements from set s.
+// The allocated memory is retained.
+func (s *CompactSet[K]) Clear() {
+	clear(s.slots)
+	s.n = 0
+	s.hasZero = false
+}
+
+// Contains reports whether element v is in set s.
+func (s *CompactSet[K]) Contains(v K) bool {
+	var zero K
+	if v == zero {
+		return s.hasZero
+	}
+	_, ok := s.find(v)
+	return ok
+}
+
+// Delete removes elements v from set s.
+// It returns the number of deleted elements.
+// Elements that are not found in the set are ignored.
+func (s *CompactSet[K]) Delete(v ...K) int {
+	var zero K
+	var c int
+	for _, k := range v {
+		if k == zero {
+			if s.hasZero {
+				s.hasZero = false
+				c++
+			}
+			continue
+		}
+		i, ok := s.find(k)
+		if !ok {
+			continue
+		}
+		// shift following elements back until reaching an empty slot or an element at its home
+		mask := len(s.slots) - 1
+		for {
+			j := (i + 1) & mask
+			if s.slots[j] == zero || s.distance(s.slots[j], j) == 0 {
+				break
+			}
+			s.slots[i] = s.slots[j]
+			i = j
+		}
+		s.slots[i] = zero
+		s.n--
+		c++
+	}
+	return c
+}
+
+// Size returns the number of elements in set s. An empty set returns 0.
+func (s *CompactSet[K]) Size() int {
+	if s.hasZero {
+		return s.n + 1
+	}
+	return s.n
+}
+
+// find returns the slot of k and reports whether it was found.
+func (s *CompactSet[K]) find(k K) (int, bool) {
+	if len(s.slots) == 0 {
+		return 0, false
+	}
+	var zero K
+	mask := len(s.slots) - 1
+	i := s.home(k)
+	for d := 0; ; d++ {
+		x := s.slots[i]
+		if x == zero || s.distance(x, i) < d {
+			return 0, false
+		}
+		if x == k {
+			return i, true
+		}
+		i = (i + 1) & mask
+	}
+}
+
+// insert inserts k and reports whether it was added.
+// The table must have at least one empty slot.
+func (s *CompactSet[K]) insert(k K) bool {
+	var zero K
+	mask := len(s.slots) - 1
+	i := s.home(k)
+	for d := 0; ; d++ {
+		x := s.slots[i]
+		if x == zero {
+			s.slots[i] = k
+			return true
+		}
+		if x == k {
+			return false
+		}
+		// Robin Hood: take the slot from elements which are closer to their home
+		if xd := s.distance(x, i); xd < d {
+			s.slots[i], k = k, x
+			d = xd
+		}
+		i = (i + 1) & mask
+	}
+}
+
+// resize rebuilds the table with space for at least n elements.
+func (s *CompactSet[K]) resize(n int) {
+	size := 8
+	for size*compactMaxLoad < n*8 {
+		size *= 2
+	}
+	if s.slots == nil {
+		s.seed = maphash.MakeSeed()
+	}
+	old := s.slots
+	s.slots = make([]K, size)
+	var zero K
+	for _, k := range old {
+		if k != zero {
+			s.insert(k)
+		}
+	}
+}
+
+func (s *CompactSet[K]) home(k K) int {
+	b := unsafe.Slice((*byte)(unsafe.Pointer(&k)), unsafe.Sizeof(k))
+	return int(maphash.Bytes(s.seed, b) & uint64(len(s.slots)-1))
+}
+
+// distance returns how far k at slot i is away from its home slot.
+func (s *CompactSet[K]) distance(k K, i int) int {
+	return (i - s.home(k)) & (len(s.slots) - 1)
+}
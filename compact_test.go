@@ -0,0 +1,141 @@
+package set_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+type uuid [16]byte
+
+func TestCompactSet(t *testing.T) {
+	a := uuid{1}
+	b := uuid{2}
+	c := uuid{3}
+	var zero uuid
+	t.Run("can add and check elements", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			add      []uuid
+			v        uuid
+			want     bool
+			wantSize int
+		}{
+			{"contains element", []uuid{a, b}, a, true, 2},
+			{"does not contain element", []uuid{a, b}, c, false, 2},
+			{"contains zero key", []uuid{a, zero}, zero, true, 2},
+			{"does not contain zero key", []uuid{a}, zero, false, 1},
+			{"duplicates", []uuid{a, a, zero, zero}, a, true, 2},
+			{"empty", []uuid{}, a, false, 0},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				var s set.CompactSet[uuid]
+				s.Add(tc.add...)
+				if got := s.Contains(tc.v); got != tc.want {
+					t.Errorf("got %v, wanted %v", got, tc.want)
+				}
+				if got := s.Size(); got != tc.wantSize {
+					t.Errorf("got %v, wanted %v", got, tc.wantSize)
+				}
+			})
+		}
+	})
+	t.Run("can delete elements", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			add      []uuid
+			del      []uuid
+			want     set.Set[uuid]
+			wantDels int
+		}{
+			{"existing element", []uuid{a, b}, []uuid{a}, set.Of(b), 1},
+			{"missing element", []uuid{a, b}, []uuid{c}, set.Of(a, b), 0},
+			{"zero key", []uuid{a, zero}, []uuid{zero, zero}, set.Of(a), 1},
+			{"missing zero key", []uuid{a}, []uuid{zero}, set.Of(a), 0},
+			{"empty", []uuid{}, []uuid{a}, set.Of[uuid](), 0},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				var s set.CompactSet[uuid]
+				s.Add(tc.add...)
+				if got := s.Delete(tc.del...); got != tc.wantDels {
+					t.Errorf("got %v, wanted %v", got, tc.wantDels)
+				}
+				if got := set.Collect(s.All()); !got.Equal(tc.want) {
+					t.Errorf("got %v, wanted %v", got, tc.want)
+				}
+			})
+		}
+	})
+	t.Run("should behave like a set for many random operations", func(t *testing.T) {
+		r := rand.New(rand.NewPCG(1, 2))
+		key := func() uuid {
+			var k uuid
+			k[0] = byte(r.IntN(8))
+			k[1] = byte(r.IntN(256))
+			return k
+		}
+		s := set.NewCompactSet[uuid](10)
+		var want set.Set[uuid]
+		for range 20_000 {
+			k := key()
+			if r.IntN(3) == 0 {
+				if got, want := s.Delete(k), want.Delete(k); got != want {
+					t.Fatalf("delete: got %v, wanted %v", got, want)
+				}
+			} else {
+				s.Add(k)
+				want.Add(k)
+			}
+			k = key()
+			if got, want := s.Contains(k), want.Contains(k); got != want {
+				t.Fatalf("contains: got %v, wanted %v", got, want)
+			}
+		}
+		if got := set.Collect(s.All()); !got.Equal(want) {
+			t.Errorf("got %d elements, wanted %d", got.Size(), want.Size())
+		}
+		if s.Size() != want.Size() {
+			t.Errorf("got %v, wanted %v", s.Size(), want.Size())
+		}
+	})
+	t.Run("can clear", func(t *testing.T) {
+		var s set.CompactSet[uuid]
+		s.Add(a, b, zero)
+		s.Clear()
+		if s.Size() != 0 || s.Contains(a) || s.Contains(zero) {
+			t.Errorf("set not cleared")
+		}
+		s.Add(c)
+		if !s.Contains(c) {
+			t.Errorf("can not reuse set after clear")
+		}
+	})
+	t.Run("can stop iteration early", func(t *testing.T) {
+		var s set.CompactSet[uuid]
+		s.Add(a, b, zero)
+		for range 3 {
+			var n int
+			for range s.All() {
+				n++
+				break
+			}
+			if n != 1 {
+				t.Errorf("got %v, wanted 1", n)
+			}
+			s.Delete(zero)
+		}
+	})
+	t.Run("should not allocate for lookups", func(t *testing.T) {
+		s := set.NewCompactSet[uuid](2)
+		s.Add(a, b)
+		n := testing.AllocsPerRun(100, func() {
+			s.Contains(c)
+		})
+		if n != 0 {
+			t.Errorf("got %v allocations, wanted 0", n)
+		}
+	})
+}
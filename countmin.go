@@ -0,0 +1,152 @@
+package set
+
+import "encoding/binary"
+
+// A CountMinSketch estimates how often elements have been added
+// using a fixed amount of memory, independent of the number of distinct elements.
+//
+// Estimates are never lower than the true count, but can be higher due to hash collisions.
+// With a width of w and a depth of d the estimate exceeds the true count
+// by more than 2/w times the total count with a probability of at most 1/2^d.
+//
+// Sketches can only be merged when they have the same dimensions
+// and use the same hash function. For merging sketches between processes
+// they can be transferred with [CountMinSketch.MarshalBinary]
+// and the hash function needs to be deterministic, e.g. one based on hash/fnv.
+type CountMinSketch[E comparable] struct {
+	width  int
+	depth  int
+	counts []uint64
+	hash   func(E) uint64
+	total  uint64
+}
+
+// NewCountMinSketch returns a new count-min sketch with depth rows of width counters each,
+// which uses the function hash to hash elements.
+// It panics if width or depth are not positive.
+func NewCountMinSketch[E comparable](width, depth int, hash func(E) uint64) *CountMinSketch[E] {
+	if width < 1 || depth < 1 {
+		panic("set.NewCountMinSketch: width and depth must be positive")
+	}
+	cms := &CountMinSketch[E]{
+		width:  width,
+		depth:  depth,
+		counts: make([]uint64, width*depth),
+		hash:   hash,
+	}
+	return cms
+}
+
+// Add increments the count of elements v by one.
+func (cms *CountMinSketch[E]) Add(v ...E) {
+	for _, w := range v {
+		cms.AddCount(w, 1)
+	}
+}
+
+// AddCount increments the count of element v by n.
+func (cms *CountMinSketch[E]) AddCount(v E, n uint64) {
+	h1, h2 := cms.hashes(v)
+	for i := range cms.depth {
+		cms.counts[cms.index(i, h1, h2)] += n
+	}
+	cms.total += n
+}
+
+// EstimateCount returns the estimated count of element v.
+func (cms *CountMinSketch[E]) EstimateCount(v E) uint64 {
+	h1, h2 := cms.hashes(v)
+	var r uint64
+	for i := range cms.depth {
+		c := cms.counts[cms.index(i, h1, h2)]
+		if i == 0 || c < r {
+			r = c
+		}
+	}
+	return r
+}
+
+// Merge adds the counts of sketch u to sketch cms.
+// It panics if both sketches do not have the same dimensions.
+func (cms *CountMinSketch[E]) Merge(u *CountMinSketch[E]) {
+	if cms.width != u.width || cms.depth != u.depth {
+		panic("set.CountMinSketch.Merge: different dimensions")
+	}
+	for i, c := range u.counts {
+		cms.counts[i] += c
+	}
+	cms.total += u.total
+}
+
+// MarshalBinary returns the binary encoding of sketch cms.
+// The hash function is not encoded.
+func (cms *CountMinSketch[E]) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 3*binary.MaxVarintLen64+len(cms.counts)*2)
+	b = binary.AppendUvarint(b, uint64(cms.width))
+	b = binary.AppendUvarint(b, uint64(cms.depth))
+	b = binary.AppendUvarint(b, cms.total)
+	for _, c := range cms.counts {
+		b = binary.AppendUvarint(b, c)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary parses the binary encoded data b and replaces the dimensions and counts of sketch cms.
+// The hash function is not encoded, so cms should be created with [NewCountMinSketch]
+// using the same hash function as the encoded sketch.
+func (cms *CountMinSketch[E]) UnmarshalBinary(b []byte) error {
+	var h [3]uint64 // width, depth, total
+	for i := range h {
+		x, k := binary.Uvarint(b)
+		if k <= 0 {
+			return errInvalidCountMinSketch
+		}
+		h[i] = x
+		b = b[k:]
+	}
+	width, depth, total := h[0], h[1], h[2]
+	// each counter needs at least one byte
+	if width == 0 || depth == 0 || width > uint64(len(b)) || depth > uint64(len(b))/width {
+		return errInvalidCountMinSketch
+	}
+	counts := make([]uint64, width*depth)
+	var sum uint64
+	for i := range counts {
+		c, k := binary.Uvarint(b)
+		if k <= 0 {
+			return errInvalidCountMinSketch
+		}
+		counts[i] = c
+		sum += c
+		b = b[k:]
+		if uint64(i+1)%width == 0 {
+			if sum != total { // each row has all counts
+				return errInvalidCountMinSketch
+			}
+			sum = 0
+		}
+	}
+	if len(b) != 0 {
+		return errInvalidCountMinSketch
+	}
+	cms.width = int(width)
+	cms.depth = int(depth)
+	cms.counts = counts
+	cms.total = total
+	return nil
+}
+
+// Total returns the sum of all counts added to sketch cms.
+func (cms *CountMinSketch[E]) Total() uint64 {
+	return cms.total
+}
+
+func (cms *CountMinSketch[E]) hashes(v E) (uint64, uint64) {
+	h := cms.hash(v)
+	return mix64(h), mix64(h^0x9e3779b97f4a7c15) | 1
+}
+
+// index returns the index of the counter in row i using double hashing.
+func (cms *CountMinSketch[E]) index(i int, h1, h2 uint64) int {
+	return i*cms.width + int((h1+uint64(i)*h2)%uint64(cms.width))
+}
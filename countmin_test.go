@@ -0,0 +1,127 @@
+package set_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestCountMinSketch(t *testing.T) {
+	t.Run("can estimate counts", func(t *testing.T) {
+		cms := set.NewCountMinSketch(1000, 5, hashInt)
+		cms.Add(1, 2, 2)
+		cms.AddCount(3, 100)
+		cases := []struct {
+			v    int
+			want uint64
+		}{
+			{1, 1},
+			{2, 2},
+			{3, 100},
+			{4, 0},
+		}
+		for _, tc := range cases {
+			got := cms.EstimateCount(tc.v)
+			if got != tc.want {
+				t.Errorf("%d: got %v, wanted %v", tc.v, got, tc.want)
+			}
+		}
+		if got := cms.Total(); got != 103 {
+			t.Errorf("got %v, wanted 103", got)
+		}
+	})
+	t.Run("should never underestimate", func(t *testing.T) {
+		cms := set.NewCountMinSketch(10, 3, hashInt)
+		for i := range 1000 {
+			cms.AddCount(i, uint64(i%7))
+		}
+		for i := range 1000 {
+			if got := cms.EstimateCount(i); got < uint64(i%7) {
+				t.Fatalf("%d: got %v, wanted at least %v", i, got, i%7)
+			}
+		}
+	})
+	t.Run("can merge", func(t *testing.T) {
+		a := set.NewCountMinSketch(1000, 5, hashInt)
+		a.Add(1, 2)
+		b := set.NewCountMinSketch(1000, 5, hashInt)
+		b.Add(2, 3)
+		a.Merge(b)
+		for v, want := range map[int]uint64{1: 1, 2: 2, 3: 1} {
+			if got := a.EstimateCount(v); got != want {
+				t.Errorf("%d: got %v, wanted %v", v, got, want)
+			}
+		}
+		if got := a.Total(); got != 4 {
+			t.Errorf("got %v, wanted 4", got)
+		}
+	})
+	t.Run("can marshal and unmarshal", func(t *testing.T) {
+		cms1 := set.NewCountMinSketch(100, 3, hashInt)
+		cms1.Add(1, 2, 2)
+		b, err := cms1.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		cms2 := set.NewCountMinSketch(1, 1, hashInt)
+		if err := cms2.UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := cms2.EstimateCount(2), uint64(2); got != want {
+			t.Errorf("got %d, wanted %d", got, want)
+		}
+		if got, want := cms2.Total(), uint64(3); got != want {
+			t.Errorf("got %d, wanted %d", got, want)
+		}
+		cms2.Merge(cms1)
+		if got, want := cms2.EstimateCount(1), uint64(2); got != want {
+			t.Errorf("got %d, wanted %d", got, want)
+		}
+	})
+	t.Run("should return error when unmarshalling invalid data", func(t *testing.T) {
+		cms := set.NewCountMinSketch(2, 2, hashInt)
+		cms.Add(1)
+		valid, err := cms.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		cases := []struct {
+			name string
+			b    []byte
+		}{
+			{"empty", []byte{}},
+			{"zero width", []byte{0, 1, 0}},
+			{"zero depth", []byte{1, 0, 0}},
+			{"too large dimensions", []byte{0xff, 0xff, 0xff, 0xff, 0x0f, 2, 0, 0}},
+			{"truncated", valid[:len(valid)-1]},
+			{"trailing data", append(valid, 0)},
+			{"row sum differs from total", []byte{2, 1, 1, 0, 0}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				var x set.CountMinSketch[int]
+				err := x.UnmarshalBinary(tc.b)
+				if !errors.Is(err, set.ErrInvalidEncoding) {
+					t.Errorf("got %q, wanted %q", err, set.ErrInvalidEncoding)
+				}
+			})
+		}
+	})
+	t.Run("should panic when merging sketches with different dimensions", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic when it was expected to")
+			}
+		}()
+		set.NewCountMinSketch(10, 3, hashInt).Merge(set.NewCountMinSketch(10, 4, hashInt))
+	})
+	t.Run("should panic when dimensions are not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic when it was expected to")
+			}
+		}()
+		set.NewCountMinSketch(0, 3, hashInt)
+	})
+}
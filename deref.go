@@ -0,0 +1,95 @@
+package set
+
+import (
+	"iter"
+	"maps"
+)
+
+// A DerefSet is a set of pointers, which are compared by the values they point to.
+//
+// Two distinct pointers to equal values are the same element of a DerefSet,
+// while they would be different elements of a Set[*T].
+// The set keeps the first pointer added for each value.
+// A nil pointer is a valid element, which is different from all non-nil pointers.
+//
+// The zero value of a DerefSet is an empty set ready to use.
+// DerefSet is not safe for concurrent use.
+type DerefSet[T comparable] struct {
+	m      map[T]*T
+	hasNil bool
+}
+
+// Add adds pointers v to set s.
+// Pointers to values, which are already in the set, are ignored.
+func (s *DerefSet[T]) Add(v ...*T) {
+	for _, p := range v {
+		if p == nil {
+			s.hasNil = true
+			continue
+		}
+		if s.m == nil {
+			s.m = make(map[T]*T)
+		}
+		if _, ok := s.m[*p]; !ok {
+			s.m[*p] = p
+		}
+	}
+}
+
+// All returns on iterator over all pointers of set s.
+//
+// Note that the order of the pointers is undefined.
+func (s *DerefSet[T]) All() iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		if s.hasNil && !yield(nil) {
+			return
+		}
+		for _, p := range s.m {
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// Contains reports whether a pointer to the same value as v is in set s.
+func (s *DerefSet[T]) Contains(v *T) bool {
+	if v == nil {
+		return s.hasNil
+	}
+	_, ok := s.m[*v]
+	return ok
+}
+
+// Delete removes the pointers to the same values as v from set s.
+// It returns the number of deleted pointers.
+func (s *DerefSet[T]) Delete(v ...*T) int {
+	var c int
+	for _, p := range v {
+		if p == nil {
+			if s.hasNil {
+				s.hasNil = false
+				c++
+			}
+			continue
+		}
+		if _, ok := s.m[*p]; ok {
+			delete(s.m, *p)
+			c++
+		}
+	}
+	return c
+}
+
+// Size returns the number of pointers in set s.
+func (s *DerefSet[T]) Size() int {
+	if s.hasNil {
+		return len(s.m) + 1
+	}
+	return len(s.m)
+}
+
+// Values returns a new set with the values the non-nil pointers of s point to.
+func (s *DerefSet[T]) Values() Set[T] {
+	return Collect(maps.Keys(s.m))
+}
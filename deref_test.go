@@ -0,0 +1,87 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestDerefSet(t *testing.T) {
+	type config struct {
+		Name string
+		Port int
+	}
+	a1 := &config{"a", 1}
+	a2 := &config{"a", 1}
+	b := &config{"b", 2}
+	t.Run("should treat pointers to equal values as same element", func(t *testing.T) {
+		var s set.DerefSet[config]
+		s.Add(a1, a2, b)
+		if s.Size() != 2 {
+			t.Errorf("got %v, wanted 2", s.Size())
+		}
+		if !s.Contains(&config{"a", 1}) || s.Contains(&config{"a", 2}) {
+			t.Errorf("contains reported wrong result")
+		}
+		var got []*config
+		for p := range s.All() {
+			if *p == *a1 {
+				got = append(got, p)
+			}
+		}
+		if len(got) != 1 || got[0] != a1 {
+			t.Errorf("expected first pointer to be kept")
+		}
+	})
+	t.Run("can handle nil pointers", func(t *testing.T) {
+		var s set.DerefSet[config]
+		if s.Contains(nil) {
+			t.Errorf("got true, wanted false")
+		}
+		s.Add(nil, a1, nil)
+		if !s.Contains(nil) || s.Size() != 2 {
+			t.Errorf("nil pointer not added")
+		}
+		var n int
+		for p := range s.All() {
+			if p == nil {
+				n++
+			}
+		}
+		if n != 1 {
+			t.Errorf("got %v, wanted 1", n)
+		}
+		if got := s.Delete(nil, nil); got != 1 {
+			t.Errorf("got %v, wanted 1", got)
+		}
+		if s.Contains(nil) || s.Size() != 1 {
+			t.Errorf("nil pointer not deleted")
+		}
+	})
+	t.Run("can delete", func(t *testing.T) {
+		var s set.DerefSet[config]
+		s.Add(a1, b)
+		if got := s.Delete(a2, &config{"c", 3}); got != 1 {
+			t.Errorf("got %v, wanted 1", got)
+		}
+		want := set.Of(*b)
+		if got := s.Values(); !got.Equal(want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+	t.Run("can stop iteration early", func(t *testing.T) {
+		var s set.DerefSet[config]
+		s.Add(nil, a1, b)
+		for range 2 {
+			var n int
+			for range s.All() {
+				n++
+				break
+			}
+			if n != 1 {
+				t.Errorf("got %v, wanted 1", n)
+			}
+			s.Delete(nil)
+		}
+	})
+}
@@ -0,0 +1,257 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+// eliasFanoSample is the distance between the sampled positions of ones and zeros
+// in the upper bits of an EliasFanoSet.
+const eliasFanoSample = 256
+
+// An EliasFanoSet is an immutable set of integers, which is stored with the Elias-Fano encoding.
+//
+// Elements are stored in ascending order relative to the smallest element.
+// The lower bits of each element are stored in a packed bit array
+// and the upper bits are stored in unary code as gaps in a second bit array,
+// which needs about 2 + log2(u/n) bits per element, where u is the distance
+// between the smallest and largest element and n the number of elements.
+// This makes it a good choice for large sets of clustered IDs, e.g. posting lists.
+//
+// The compressed form can be queried directly:
+// looking up elements and computing their rank takes constant time on average
+// and accessing an element by position with [EliasFanoSet.Select] takes constant time.
+//
+// The zero value of an EliasFanoSet is an empty set ready to use.
+// An EliasFanoSet is safe for concurrent use, because it can not be modified.
+type EliasFanoSet[E integer] struct {
+	n      int
+	lo     E    // smallest element
+	hi     E    // largest element
+	l      uint // number of lower bits
+	lower  []uint64
+	upper  []uint64
+	ones   []int // position of every eliasFanoSample-th one in upper
+	zeros  []int // position of every eliasFanoSample-th zero in upper
+	length int   // number of bits in upper
+}
+
+// EliasFanoOf returns a new Elias-Fano set with the elements v.
+func EliasFanoOf[E integer](v ...E) *EliasFanoSet[E] {
+	return newEliasFanoSet(slices.Clone(v))
+}
+
+// CollectEliasFano collects values from seq into a new Elias-Fano set and returns it.
+func CollectEliasFano[E integer](seq iter.Seq[E]) *EliasFanoSet[E] {
+	return newEliasFanoSet(slices.Collect(seq))
+}
+
+// newEliasFanoSet returns a new Elias-Fano set from slice v, which is modified.
+func newEliasFanoSet[E integer](v []E) *EliasFanoSet[E] {
+	slices.Sort(v)
+	v = slices.Compact(v)
+	s := &EliasFanoSet[E]{n: len(v)}
+	if len(v) == 0 {
+		return s
+	}
+	s.lo, s.hi = v[0], v[len(v)-1]
+	u := s.offset(s.hi)
+	if q := u / uint64(len(v)); q > 0 {
+		s.l = uint(bits.Len64(q) - 1)
+	}
+	s.length = len(v) + int(u>>s.l) + 1
+	s.upper = make([]uint64, (s.length+63)/64)
+	s.lower = make([]uint64, (uint(len(v))*s.l+63)/64)
+	var zeros int
+	prev := -1 // position of the previous one
+	for i, x := range v {
+		d := s.offset(x)
+		p := int(d>>s.l) + i
+		for z := prev + 1; z < p; z++ {
+			if zeros%eliasFanoSample == 0 {
+				s.zeros = append(s.zeros, z)
+			}
+			zeros++
+		}
+		if i%eliasFanoSample == 0 {
+			s.ones = append(s.ones, p)
+		}
+		s.upper[p/64] |= 1 << (p % 64)
+		s.setLower(i, d)
+		prev = p
+	}
+	for z := prev + 1; z < s.length; z++ {
+		if zeros%eliasFanoSample == 0 {
+			s.zeros = append(s.zeros, z)
+		}
+		zeros++
+	}
+	return s
+}
+
+// All returns an iterator over all elements of set s in ascending order.
+func (s *EliasFanoSet[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		var i int
+		for k, w := range s.upper {
+			for w != 0 {
+				p := k*64 + bits.TrailingZeros64(w)
+				if !yield(s.value(i, uint64(p-i))) {
+					return
+				}
+				w &= w - 1
+				i++
+			}
+		}
+	}
+}
+
+// Contains reports whether element v is in set s.
+func (s *EliasFanoSet[E]) Contains(v E) bool {
+	_, found := s.rank(v)
+	return found
+}
+
+// Rank returns the number of elements in set s, which are less than v.
+// For elements of the set this is their position in ascending order.
+func (s *EliasFanoSet[E]) Rank(v E) int {
+	i, _ := s.rank(v)
+	return i
+}
+
+// Select returns the element at position i of set s in ascending order.
+// It panics if i is out of range.
+func (s *EliasFanoSet[E]) Select(i int) E {
+	if i < 0 || i >= s.n {
+		panic(fmt.Sprintf("set.EliasFanoSet.Select: index %d out of range with size %d", i, s.n))
+	}
+	p := s.select1(i)
+	return s.value(i, uint64(p-i))
+}
+
+// Size returns the number of elements in set s. An empty set returns 0.
+func (s *EliasFanoSet[E]) Size() int {
+	return s.n
+}
+
+// String returns a string representation of set s.
+// Sets are printed with curly brackets in ascending order, e.g. {1 2 10}.
+func (s *EliasFanoSet[E]) String() string {
+	var b strings.Builder
+	b.WriteByte('{')
+	var i int
+	for v := range s.All() {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprint(&b, v)
+		i++
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// rank returns the number of elements less than v and reports whether v is in set s.
+func (s *EliasFanoSet[E]) rank(v E) (int, bool) {
+	if s.n == 0 || v < s.lo {
+		return 0, false
+	}
+	if v > s.hi {
+		return s.n, false
+	}
+	d := s.offset(v)
+	h := int(d >> s.l)
+	// find the first element with upper bits h, which follows the h-th zero
+	var i, p int
+	if h > 0 {
+		p = s.select0(h-1) + 1
+		i = p - h
+	}
+	low := d & s.lowerMask()
+	for ; p < s.length && s.upper[p/64]&(1<<(p%64)) != 0; p, i = p+1, i+1 {
+		if x := s.getLower(i); x >= low {
+			return i, x == low
+		}
+	}
+	return i, false
+}
+
+// select1 returns the position of the one with index k in the upper bits.
+func (s *EliasFanoSet[E]) select1(k int) int {
+	return selectBit(s.upper, s.ones[k/eliasFanoSample], k%eliasFanoSample, false)
+}
+
+// select0 returns the position of the zero with index k in the upper bits.
+func (s *EliasFanoSet[E]) select0(k int) int {
+	return selectBit(s.upper, s.zeros[k/eliasFanoSample], k%eliasFanoSample, true)
+}
+
+func (s *EliasFanoSet[E]) getLower(i int) uint64 {
+	if s.l == 0 {
+		return 0
+	}
+	pos := uint(i) * s.l
+	k, off := pos/64, pos%64
+	x := s.lower[k] >> off
+	if off+s.l > 64 {
+		x |= s.lower[k+1] << (64 - off)
+	}
+	return x & s.lowerMask()
+}
+
+func (s *EliasFanoSet[E]) setLower(i int, d uint64) {
+	if s.l == 0 {
+		return
+	}
+	x := d & s.lowerMask()
+	pos := uint(i) * s.l
+	k, off := pos/64, pos%64
+	s.lower[k] |= x << off
+	if off+s.l > 64 {
+		s.lower[k+1] |= x >> (64 - off)
+	}
+}
+
+func (s *EliasFanoSet[E]) lowerMask() uint64 {
+	return 1<<s.l - 1
+}
+
+// offset returns the distance of v from the smallest element,
+// which can not overflow for signed elements.
+func (s *EliasFanoSet[E]) offset(v E) uint64 {
+	return uint64(v) - uint64(s.lo)
+}
+
+// value returns the element at position i with the upper bits h.
+func (s *EliasFanoSet[E]) value(i int, h uint64) E {
+	return E(uint64(s.lo) + (h<<s.l | s.getLower(i)))
+}
+
+// selectBit returns the position of the r-th set bit after position p in words,
+// where the bit at position p is set. If zeros is true, it looks for unset bits instead.
+func selectBit(words []uint64, p, r int, zeros bool) int {
+	k := p / 64
+	w := words[k]
+	if zeros {
+		w = ^w
+	}
+	w &= ^uint64(0) << (p % 64)
+	for {
+		c := bits.OnesCount64(w)
+		if r < c {
+			for range r {
+				w &= w - 1
+			}
+			return k*64 + bits.TrailingZeros64(w)
+		}
+		r -= c
+		k++
+		w = words[k]
+		if zeros {
+			w = ^w
+		}
+	}
+}
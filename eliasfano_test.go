@@ -0,0 +1,153 @@
+package set_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestEliasFanoSet(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	cases := []struct {
+		name string
+		v    []int
+	}{
+		{"single element", []int{42}},
+		{"consecutive", slices.Collect(set.Range(0, 1000, 1).All())},
+		{"sparse", slices.Collect(set.Range(-1_000_000, 1_000_000, 997).All())},
+		{"extremes", []int{math.MinInt, -1, 0, 1, math.MaxInt}},
+		{"random", func() []int {
+			var v []int
+			for range 5000 {
+				v = append(v, r.IntN(1<<30))
+			}
+			return v
+		}()},
+		{"clustered", func() []int {
+			var v []int
+			for i := range 100 {
+				for j := range 50 {
+					v = append(v, i*100_000+j)
+				}
+			}
+			return v
+		}()},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := set.EliasFanoOf(tc.v...)
+			want := slices.Compact(slices.Sorted(slices.Values(tc.v)))
+			if got := s.Size(); got != len(want) {
+				t.Errorf("got %v, wanted %v", got, len(want))
+			}
+			if got := slices.Collect(s.All()); !slices.Equal(got, want) {
+				t.Fatalf("got %v, wanted %v", got, want)
+			}
+			for i, v := range want {
+				if got := s.Select(i); got != v {
+					t.Fatalf("select %d: got %v, wanted %v", i, got, v)
+				}
+				if got := s.Rank(v); got != i {
+					t.Fatalf("rank %d: got %v, wanted %v", v, got, i)
+				}
+				if !s.Contains(v) {
+					t.Fatalf("expected %v to be contained", v)
+				}
+				if v > math.MinInt && !slices.Contains(want, v-1) {
+					if s.Contains(v - 1) {
+						t.Fatalf("expected %v to not be contained", v-1)
+					}
+					if got := s.Rank(v - 1); got != i {
+						t.Fatalf("rank %d: got %v, wanted %v", v-1, got, i)
+					}
+				}
+				if v < math.MaxInt && !slices.Contains(want, v+1) {
+					if s.Contains(v + 1) {
+						t.Fatalf("expected %v to not be contained", v+1)
+					}
+					if got := s.Rank(v + 1); got != i+1 {
+						t.Fatalf("rank %d: got %v, wanted %v", v+1, got, i+1)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEliasFanoSet_Types(t *testing.T) {
+	t.Run("int8", func(t *testing.T) {
+		s := set.EliasFanoOf[int8](-128, -1, 0, 127)
+		if got, want := s.String(), "{-128 -1 0 127}"; got != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+		if s.Contains(1) {
+			t.Errorf("expected 1 to not be contained")
+		}
+	})
+	t.Run("uint64", func(t *testing.T) {
+		s := set.CollectEliasFano(slices.Values([]uint64{math.MaxUint64, 0, 1 << 63}))
+		if got, want := slices.Collect(s.All()), []uint64{0, 1 << 63, math.MaxUint64}; !slices.Equal(got, want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+		if got := s.Rank(math.MaxUint64 - 1); got != 2 {
+			t.Errorf("got %v, wanted 2", got)
+		}
+	})
+}
+
+func TestEliasFanoSet_Bounds(t *testing.T) {
+	s := set.EliasFanoOf(10, 20, 30)
+	cases := []struct {
+		v    int
+		rank int
+	}{
+		{math.MinInt, 0},
+		{9, 0},
+		{15, 1},
+		{31, 3},
+		{math.MaxInt, 3},
+	}
+	for _, tc := range cases {
+		if got := s.Rank(tc.v); got != tc.rank {
+			t.Errorf("rank %d: got %v, wanted %v", tc.v, got, tc.rank)
+		}
+		if s.Contains(tc.v) {
+			t.Errorf("expected %v to not be contained", tc.v)
+		}
+	}
+	t.Run("can stop iterating", func(t *testing.T) {
+		var got []int
+		for v := range s.All() {
+			got = append(got, v)
+			break
+		}
+		if want := []int{10}; !slices.Equal(got, want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+	t.Run("should panic when selecting out of range", func(t *testing.T) {
+		for _, i := range []int{-1, 3} {
+			func() {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Errorf("The code did not panic when it was expected to")
+					}
+				}()
+				s.Select(i)
+			}()
+		}
+	})
+}
+
+func TestEliasFanoSet_ZeroValue(t *testing.T) {
+	var s set.EliasFanoSet[int]
+	if s.Size() != 0 || s.Contains(0) || s.Rank(1) != 0 || s.String() != "{}" {
+		t.Errorf("wanted empty set, got %v", &s)
+	}
+	if got := set.EliasFanoOf[int](); got.Size() != 0 || got.String() != "{}" {
+		t.Errorf("wanted empty set, got %v", got)
+	}
+}
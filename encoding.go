@@ -0,0 +1,412 @@
+package set
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+)
+
+// binaryAppender is implemented by types which can append their binary encoding to a buffer,
+// e.g. types implementing encoding.BinaryAppender.
+type binaryAppender interface {
+	AppendBinary(b []byte) ([]byte, error)
+}
+
+// AppendText appends the JSON encoding of set s to b and returns the extended buffer.
+// The encoding is the same as for [Set.MarshalJSON].
+//
+// AppendText implements the encoding.TextAppender interface.
+// It allows encoding sets into a reused buffer
+// without allocations for elements of basic types like strings and integers.
+func (s Set[E]) AppendText(b []byte) ([]byte, error) {
+	if s.m == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '[')
+	first := true
+	for v := range s.m {
+		if !first {
+			b = append(b, ',')
+		}
+		first = false
+		var err error
+		b, err = appendJSON(b, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return append(b, ']'), nil
+}
+
+// AppendBinary appends the binary encoding of set s to b and returns the extended buffer.
+//
+// Elements are encoded with their AppendBinary or MarshalBinary methods if they have one,
+// including methods with pointer receivers.
+// Elements of basic types are encoded directly, integers as varints
+// and other fixed-size types like floats in little endian byte order.
+// Elements of all other types are encoded with their MarshalText method if they have one.
+// It returns an error for all other element types.
+// Zero sets are encoded as empty data.
+//
+// AppendBinary implements the encoding.BinaryAppender interface.
+func (s Set[E]) AppendBinary(b []byte) ([]byte, error) {
+	if s.m == nil {
+		return b, nil
+	}
+	b = binary.AppendUvarint(b, uint64(len(s.m)))
+	e := newBinaryEncoder[E]()
+	for v := range s.m {
+		var err error
+		b, err = e.append(b, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// MarshalBinary returns the binary encoding of set s as described for [Set.AppendBinary].
+func (s Set[E]) MarshalBinary() ([]byte, error) {
+	return s.AppendBinary(nil)
+}
+
+// UnmarshalBinary parses the binary encoded data b and replaces the current set.
+// Elements are decoded with the UnmarshalBinary or UnmarshalText method matching their encoding
+// and it returns an error wrapping [ErrUnsupportedType] if the element type does not have it.
+// Empty data will be unmarshaled into a zero set.
+func (s *Set[E]) UnmarshalBinary(b []byte) error {
+	if len(b) == 0 {
+		s.m = nil
+		return nil
+	}
+	n, k := binary.Uvarint(b)
+	if k <= 0 || n > uint64(len(b)) { // each element needs at least one byte
+		return errInvalidSet
+	}
+	r := make(map[E]struct{}, n)
+	b, err := readBinaryElements(b[k:], n, func(v E) {
+		r[v] = struct{}{}
+	})
+	if err != nil {
+		return err
+	}
+	if len(b) != 0 {
+		return errInvalidSet
+	}
+	s.m = r
+	return nil
+}
+
+// appendJSON appends the JSON encoding of v to b.
+// Basic types are encoded directly, all other types with [json.Marshal].
+// Methods of v with pointer receivers are used as well.
+func appendJSON[E any](b []byte, v E) ([]byte, error) {
+	switch x := any(v).(type) {
+	case string:
+		return appendJSONString(b, x), nil
+	case bool:
+		return strconv.AppendBool(b, x), nil
+	case int:
+		return strconv.AppendInt(b, int64(x), 10), nil
+	case int8:
+		return strconv.AppendInt(b, int64(x), 10), nil
+	case int16:
+		return strconv.AppendInt(b, int64(x), 10), nil
+	case int32:
+		return strconv.AppendInt(b, int64(x), 10), nil
+	case int64:
+		return strconv.AppendInt(b, x, 10), nil
+	case uint:
+		return strconv.AppendUint(b, uint64(x), 10), nil
+	case uint8:
+		return strconv.AppendUint(b, uint64(x), 10), nil
+	case uint16:
+		return strconv.AppendUint(b, uint64(x), 10), nil
+	case uint32:
+		return strconv.AppendUint(b, uint64(x), 10), nil
+	case uint64:
+		return strconv.AppendUint(b, x, 10), nil
+	}
+	// marshal a pointer, so that methods with pointer receivers are used like for slice elements
+	// a copy is used, so that only these types are moved to the heap
+	x := v
+	j, err := json.Marshal(&x)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, j...), nil
+}
+
+// appendJSONString appends s as JSON string to b with the same escaping as [json.Marshal].
+func appendJSONString(b []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	b = append(b, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				b = append(b, '\\', c)
+			case c == '\b':
+				b = append(b, '\\', 'b')
+			case c == '\f':
+				b = append(b, '\\', 'f')
+			case c == '\n':
+				b = append(b, '\\', 'n')
+			case c == '\r':
+				b = append(b, '\\', 'r')
+			case c == '\t':
+				b = append(b, '\\', 't')
+			case c < 0x20 || c == '<' || c == '>' || c == '&':
+				b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			default:
+				b = append(b, c)
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			b = append(b, "\ufffd"...)
+		case r == '\u2028' || r == '\u2029':
+			b = append(b, '\\', 'u', '2', '0', '2', hex[r&0xf])
+		default:
+			b = append(b, s[i:i+size]...)
+		}
+		i += size
+	}
+	return append(b, '"')
+}
+
+// Methods for binary encoding elements as described for [Set.AppendBinary].
+const (
+	binaryValue     = iota // basic types and fixed-size values
+	binaryAppend           // AppendBinary method
+	binaryMarshaler        // MarshalBinary method
+	binaryText             // MarshalText method
+)
+
+// binaryMethod returns the method for binary encoding and decoding elements of type t.
+// Methods with pointer receivers are considered as well,
+// so that the same method is used for encoding and decoding.
+func binaryMethod(t reflect.Type) int {
+	p := reflect.PointerTo(t)
+	switch {
+	case p.Implements(reflect.TypeFor[binaryAppender]()):
+		return binaryAppend
+	case p.Implements(reflect.TypeFor[encoding.BinaryMarshaler]()):
+		return binaryMarshaler
+	case !isBinaryValue(t) && p.Implements(reflect.TypeFor[encoding.TextMarshaler]()):
+		return binaryText
+	}
+	return binaryValue
+}
+
+// isBinaryValue reports whether elements of type t can be encoded with [appendBinaryValue].
+func isBinaryValue(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	case reflect.Interface, reflect.Pointer:
+		return false
+	}
+	return binary.Size(reflect.Zero(t).Interface()) > 0
+}
+
+// A binaryEncoder appends the binary encoding of elements as described for [Set.AppendBinary].
+// The encoding method is determined once for the element type,
+// so that encoding elements of basic types does not allocate.
+type binaryEncoder[E comparable] struct {
+	method  int
+	v       E
+	rv      reflect.Value // refers to v
+	scratch []byte
+}
+
+func newBinaryEncoder[E comparable]() *binaryEncoder[E] {
+	e := &binaryEncoder[E]{method: binaryMethod(reflect.TypeFor[E]())}
+	if e.method == binaryValue {
+		e.rv = reflect.ValueOf(&e.v).Elem()
+	}
+	return e
+}
+
+// append appends the binary encoding of element v to b and returns the extended buffer.
+func (e *binaryEncoder[E]) append(b []byte, v E) ([]byte, error) {
+	e.v = v
+	if e.method == binaryValue {
+		return appendBinaryValue(b, e.rv)
+	}
+	var data []byte
+	var err error
+	switch p := any(&e.v); e.method {
+	case binaryAppend:
+		e.scratch, err = p.(binaryAppender).AppendBinary(e.scratch[:0])
+		data = e.scratch
+	case binaryMarshaler:
+		data, err = p.(encoding.BinaryMarshaler).MarshalBinary()
+	case binaryText:
+		data, err = p.(encoding.TextMarshaler).MarshalText()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return appendBytes(b, data), nil
+}
+
+// readBinaryElements reads n binary encoded elements from b, calls add for each of them
+// and returns the remaining buffer.
+// It returns an error if the element type can not be decoded as described for [newBinaryDecoder].
+func readBinaryElements[E comparable](b []byte, n uint64, add func(E)) ([]byte, error) {
+	d, err := newBinaryDecoder[E]()
+	if err != nil {
+		return nil, err
+	}
+	for range n {
+		var v E
+		v, b, err = d.read(b)
+		if err != nil {
+			return nil, err
+		}
+		add(v)
+	}
+	return b, nil
+}
+
+// A binaryDecoder reads elements encoded as described for [Set.AppendBinary].
+// The unmarshaler is determined once for the element type,
+// so that it does not need to be looked up for every element.
+type binaryDecoder[E comparable] struct {
+	v         E
+	rv        reflect.Value      // refers to v
+	unmarshal func([]byte) error // method of v or nil for basic types
+}
+
+// newBinaryDecoder returns a decoder, which decodes elements with the unmarshaler
+// matching the method used for encoding them.
+// It returns an error if the element type has no such unmarshaler.
+func newBinaryDecoder[E comparable]() (*binaryDecoder[E], error) {
+	d := &binaryDecoder[E]{}
+	d.rv = reflect.ValueOf(&d.v).Elem()
+	switch p := any(&d.v); binaryMethod(reflect.TypeFor[E]()) {
+	case binaryAppend, binaryMarshaler:
+		u, ok := p.(encoding.BinaryUnmarshaler)
+		if !ok {
+			return nil, fmt.Errorf("%w: can not binary decode elements of type %s without UnmarshalBinary method", ErrUnsupportedType, d.rv.Type())
+		}
+		d.unmarshal = u.UnmarshalBinary
+	case binaryText:
+		u, ok := p.(encoding.TextUnmarshaler)
+		if !ok {
+			return nil, fmt.Errorf("%w: can not binary decode elements of type %s without UnmarshalText method", ErrUnsupportedType, d.rv.Type())
+		}
+		d.unmarshal = u.UnmarshalText
+	}
+	return d, nil
+}
+
+// read reads the next element from b and returns it and the remaining buffer.
+func (d *binaryDecoder[E]) read(b []byte) (E, []byte, error) {
+	var zero E
+	d.v = zero
+	if d.unmarshal == nil {
+		b, err := readBinaryValue(b, d.rv)
+		if err != nil {
+			return zero, nil, err
+		}
+		return d.v, b, nil
+	}
+	data, b, err := readBytes(b)
+	if err != nil {
+		return zero, nil, err
+	}
+	if err := d.unmarshal(data); err != nil {
+		return zero, nil, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	return d.v, b, nil
+}
+
+// appendBytes appends data with its length as prefix to b.
+func appendBytes(b, data []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+// readBytes reads data with its length as prefix from b and returns it and the remaining buffer.
+func readBytes(b []byte) (data, rest []byte, err error) {
+	n, k := binary.Uvarint(b)
+	if k <= 0 || n > uint64(len(b)-k) {
+		return nil, nil, errInvalidSet
+	}
+	b = b[k:]
+	return b[:n], b[n:], nil
+}
+
+func appendBinaryValue(b []byte, rv reflect.Value) ([]byte, error) {
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(b, rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.AppendUvarint(b, rv.Uint()), nil
+	case reflect.String:
+		s := rv.String()
+		b = binary.AppendUvarint(b, uint64(len(s)))
+		return append(b, s...), nil
+	}
+	if k := rv.Kind(); k != reflect.Interface && k != reflect.Pointer && binary.Size(rv.Interface()) > 0 {
+		return binary.Append(b, binary.LittleEndian, rv.Interface())
+	}
+	return nil, fmt.Errorf("%w: can not binary encode elements of type %s", ErrUnsupportedType, rv.Type())
+}
+
+func readBinaryValue(b []byte, rv reflect.Value) ([]byte, error) {
+	switch rv.Kind() {
+	case reflect.Bool:
+		if len(b) == 0 || b[0] > 1 {
+			return nil, errInvalidSet
+		}
+		rv.SetBool(b[0] == 1)
+		return b[1:], nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, k := binary.Varint(b)
+		if k <= 0 || rv.OverflowInt(x) {
+			return nil, errInvalidSet
+		}
+		rv.SetInt(x)
+		return b[k:], nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x, k := binary.Uvarint(b)
+		if k <= 0 || rv.OverflowUint(x) {
+			return nil, errInvalidSet
+		}
+		rv.SetUint(x)
+		return b[k:], nil
+	case reflect.String:
+		data, rest, err := readBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		rv.SetString(string(data))
+		return rest, nil
+	}
+	if binary.Size(rv.Interface()) <= 0 {
+		return nil, fmt.Errorf("%w: can not binary decode elements of type %s", ErrUnsupportedType, rv.Type())
+	}
+	k, err := binary.Decode(b, binary.LittleEndian, rv.Addr().Interface())
+	if err != nil {
+		return nil, errInvalidSet
+	}
+	return b[k:], nil
+}
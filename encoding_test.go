@@ -0,0 +1,415 @@
+package set_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestSet_AppendText(t *testing.T) {
+	t.Run("should encode elements like json.Marshal", func(t *testing.T) {
+		strs := []string{
+			"", "abc", `"quoted"`, `back\slash`, "<a href='x'>&</a>",
+			"\b\f\n\r\t\x00\x1f\x7f", "äöü 世界", "  ", "invalid \xff utf8",
+		}
+		for _, v := range strs {
+			checkAppendText(t, set.Of(v))
+		}
+		checkAppendText(t, set.Of(true))
+		checkAppendText(t, set.Of(-1, 0, 42))
+		checkAppendText(t, set.Of[int8](-128, 127))
+		checkAppendText(t, set.Of[int16](-1))
+		checkAppendText(t, set.Of[int32](-1))
+		checkAppendText(t, set.Of[int64](-1<<63))
+		checkAppendText(t, set.Of[uint](1))
+		checkAppendText(t, set.Of[uint8](255))
+		checkAppendText(t, set.Of[uint16](1))
+		checkAppendText(t, set.Of[uint32](1))
+		checkAppendText(t, set.Of[uint64](1<<64-1))
+		checkAppendText(t, set.Of(1.5, 1e21))
+		checkAppendText(t, set.Of(caseless("a")))
+		checkAppendText(t, set.Of[int]())
+		checkAppendText(t, set.Set[int]{})
+	})
+	t.Run("should use marshalers with pointer receivers", func(t *testing.T) {
+		cases := []struct {
+			name string
+			s    interface{ MarshalJSON() ([]byte, error) }
+			want string
+		}{
+			{"json marshaler", set.Of(pointerJSON{1}), `["custom 1"]`},
+			{"text marshaler", set.Of(pointerText("a")), `["text a"]`},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := tc.s.MarshalJSON()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(got) != tc.want {
+					t.Errorf("got %s, wanted %s", got, tc.want)
+				}
+			})
+		}
+	})
+	t.Run("should append to existing buffer", func(t *testing.T) {
+		got, err := set.Of(1).AppendText([]byte("ids="))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "ids=[1]"; string(got) != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+	t.Run("should return error when element can not be encoded", func(t *testing.T) {
+		_, err := set.Of(make(chan int)).AppendText(nil)
+		if err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+	})
+	t.Run("should not allocate for basic types", func(t *testing.T) {
+		s := set.Of("alpha", "bravo")
+		buf := make([]byte, 0, 64)
+		n := testing.AllocsPerRun(100, func() {
+			buf, _ = s.AppendText(buf[:0])
+		})
+		if n != 0 {
+			t.Errorf("got %v allocations, wanted 0", n)
+		}
+	})
+}
+
+func checkAppendText[E comparable](t *testing.T, s set.Set[E]) {
+	t.Helper()
+	got, err := s.AppendText(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want string
+	switch {
+	case s.IsZero():
+		want = "null"
+	case s.Size() == 0:
+		want = "[]"
+	case s.Size() == 1:
+		for v := range s.All() {
+			b, err := json.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want = "[" + string(b) + "]"
+		}
+	default:
+		var s2 set.Set[E]
+		if err := json.Unmarshal(got, &s2); err != nil {
+			t.Fatal(err)
+		}
+		if !s2.Equal(s) {
+			t.Errorf("got %q, wanted %q", s2, s)
+		}
+		return
+	}
+	if string(got) != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+type point struct {
+	X, Y float32
+}
+
+func TestSet_AppendBinary(t *testing.T) {
+	t.Run("can marshal and unmarshal", func(t *testing.T) {
+		checkBinary(t, set.Of("alpha", "", "世界"))
+		checkBinary(t, set.Of(true, false))
+		checkBinary(t, set.Of(-1, 0, 1<<40))
+		checkBinary(t, set.Of[int8](-128, 127))
+		checkBinary(t, set.Of[uint](0, 1<<63))
+		checkBinary(t, set.Of[uint8](0, 255))
+		checkBinary(t, set.Of(1.5, -2.25))
+		checkBinary(t, set.Of(point{1, 2}, point{3, 4}))
+		checkBinary(t, set.Of([2]int32{1, 2}))
+		checkBinary(t, set.Of(time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)))
+		checkBinary(t, set.Of(netip.MustParseAddr("192.168.0.1"), netip.MustParseAddr("::1")))
+		checkBinary(t, set.Of(binaryMarshaler{"a"}, binaryMarshaler{"b"}))
+		checkBinary(t, set.Of(fullName{"Ann", "Lee"}, fullName{"Bob", ""}))
+		checkBinary(t, set.Of(pointerBinary{1, 2}, pointerBinary{3, 4}))
+		checkBinary(t, set.Of[pointerCode]("a", "b"))
+		checkBinary(t, set.Of(pointerFullName{"Ann", "Lee"}))
+		checkBinary(t, set.Of[int]())
+	})
+	t.Run("should prefer direct encoding to text for basic types", func(t *testing.T) {
+		got, err := set.Of[textLevel](1).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := set.Of(1).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+	t.Run("should preserve zero sets", func(t *testing.T) {
+		b, err := set.Set[int]{}.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(b) != 0 {
+			t.Errorf("got %v, wanted empty data", b)
+		}
+		s := set.Of(1)
+		if err := s.UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+		if !s.IsZero() {
+			t.Errorf("wanted zero set")
+		}
+	})
+	t.Run("should append to existing buffer", func(t *testing.T) {
+		got, err := set.Of[uint8](7).AppendBinary([]byte{0xff})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []byte{0xff, 1, 7}; !slices.Equal(got, want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+	t.Run("should return error for unsupported types", func(t *testing.T) {
+		x := 1
+		cases := []struct {
+			name string
+			s    interface{ MarshalBinary() ([]byte, error) }
+		}{
+			{"pointer", set.Of(&x)},
+			{"interface", set.Of[any](1.5)},
+			{"variable size struct", set.Of(struct{ s string }{"a"})},
+			{"no size", set.Of(struct{}{})},
+			{"marshaler error", set.Of(binaryMarshaler{"error"})},
+			{"appender error", set.Of(errAppender{})},
+			{"text marshaler error", set.Of(fullName{"", ""})},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := tc.s.MarshalBinary()
+				if err == nil {
+					t.Errorf("got %q, wanted error", err)
+				}
+			})
+		}
+	})
+	t.Run("should not allocate per element for basic types", func(t *testing.T) {
+		s := set.Of("alpha", "bravo", "charlie", "delta")
+		buf := make([]byte, 0, 64)
+		n := testing.AllocsPerRun(100, func() {
+			buf, _ = s.AppendBinary(buf[:0])
+		})
+		if n > 1 {
+			t.Errorf("got %v allocations, wanted at most 1", n)
+		}
+	})
+}
+
+func TestSet_UnmarshalBinary(t *testing.T) {
+	cases := []struct {
+		name string
+		in   []byte
+		fn   func(b []byte) error
+	}{
+		{"count too large", []byte{5, 1}, new(set.Set[int]).UnmarshalBinary},
+		{"invalid count", []byte{0xff}, new(set.Set[int]).UnmarshalBinary},
+		{"trailing data", []byte{1, 2, 3}, new(set.Set[int]).UnmarshalBinary},
+		{"invalid int", []byte{2, 0xff, 0xff}, new(set.Set[int]).UnmarshalBinary},
+		{"int overflow", []byte{1, 0x80, 0x02}, new(set.Set[int8]).UnmarshalBinary},
+		{"invalid uint", []byte{2, 0xff, 0xff}, new(set.Set[uint]).UnmarshalBinary},
+		{"uint overflow", []byte{1, 0x80, 0x02}, new(set.Set[uint8]).UnmarshalBinary},
+		{"invalid bool", []byte{1, 2}, new(set.Set[bool]).UnmarshalBinary},
+		{"string too long", []byte{1, 5, 'a'}, new(set.Set[string]).UnmarshalBinary},
+		{"float too short", []byte{1, 1}, new(set.Set[float64]).UnmarshalBinary},
+		{"unsupported type", []byte{1, 1}, new(set.Set[*int]).UnmarshalBinary},
+		{"unmarshaler too short", []byte{1, 5, 1}, new(set.Set[binaryMarshaler]).UnmarshalBinary},
+		{"unmarshaler error", []byte{1, 1, 0xff}, new(set.Set[time.Time]).UnmarshalBinary},
+		{"text unmarshaler too short", []byte{1, 5, 'a'}, new(set.Set[fullName]).UnmarshalBinary},
+		{"text unmarshaler error", []byte{1, 1, 'a'}, new(set.Set[fullName]).UnmarshalBinary},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.fn(tc.in); err == nil {
+				t.Errorf("got %q, wanted error", err)
+			}
+		})
+	}
+	t.Run("should return error when element type has no matching unmarshaler", func(t *testing.T) {
+		cases := []struct {
+			name string
+			fn   func(b []byte) error
+		}{
+			{"appender", new(set.Set[errAppender]).UnmarshalBinary},
+			{"text marshaler", new(set.Set[textOnly]).UnmarshalBinary},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				if err := tc.fn([]byte{1, 1, 'a'}); !errors.Is(err, set.ErrUnsupportedType) {
+					t.Errorf("got %v, wanted %v", err, set.ErrUnsupportedType)
+				}
+			})
+		}
+	})
+	t.Run("should not change set when unmarshalling fails", func(t *testing.T) {
+		s := set.Of(4)
+		if err := s.UnmarshalBinary([]byte{1, 2, 3}); err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+		if want := set.Of(4); !s.Equal(want) {
+			t.Errorf("got %q, wanted %q", s, want)
+		}
+	})
+}
+
+func checkBinary[E comparable](t *testing.T, s set.Set[E]) {
+	t.Helper()
+	b, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got set.Set[E]
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(s) {
+		t.Errorf("got %v, wanted %v", got, s)
+	}
+}
+
+// binaryMarshaler is a type which implements the binary encoding interfaces with MarshalBinary.
+type binaryMarshaler struct {
+	s string
+}
+
+func (x binaryMarshaler) MarshalBinary() ([]byte, error) {
+	if x.s == "error" {
+		return nil, errTest
+	}
+	return []byte(strings.ToUpper(x.s)), nil
+}
+
+func (x *binaryMarshaler) UnmarshalBinary(b []byte) error {
+	x.s = strings.ToLower(string(b))
+	return nil
+}
+
+type errAppender struct{}
+
+func (errAppender) AppendBinary([]byte) ([]byte, error) {
+	return nil, errTest
+}
+
+// fullName is a variable size struct, which can only be encoded as text.
+type fullName struct {
+	first, last string
+}
+
+func (x fullName) MarshalText() ([]byte, error) {
+	if x.first == "" {
+		return nil, errTest
+	}
+	return []byte(x.first + " " + x.last), nil
+}
+
+func (x *fullName) UnmarshalText(b []byte) error {
+	first, last, ok := strings.Cut(string(b), " ")
+	if !ok {
+		return errTest
+	}
+	x.first, x.last = first, last
+	return nil
+}
+
+// textLevel is an integer type, which can also be encoded as text.
+type textLevel int
+
+func (x textLevel) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(int(x))), nil
+}
+
+// pointerJSON is a type, which implements json.Marshaler with a pointer receiver.
+type pointerJSON struct {
+	X int
+}
+
+func (x *pointerJSON) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("custom %d", x.X))
+}
+
+// pointerText is a type, which implements encoding.TextMarshaler with a pointer receiver.
+type pointerText string
+
+func (x *pointerText) MarshalText() ([]byte, error) {
+	return []byte("text " + string(*x)), nil
+}
+
+// pointerBinary is a fixed-size struct,
+// which implements the binary encoding interfaces with pointer receivers.
+type pointerBinary struct {
+	A, B uint32
+}
+
+func (x *pointerBinary) MarshalBinary() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d,%d", x.A, x.B)), nil
+}
+
+func (x *pointerBinary) UnmarshalBinary(b []byte) error {
+	_, err := fmt.Sscanf(string(b), "%d,%d", &x.A, &x.B)
+	return err
+}
+
+// pointerCode is an ordered type,
+// which implements the binary encoding interfaces with pointer receivers.
+type pointerCode string
+
+func (x *pointerCode) MarshalBinary() ([]byte, error) {
+	return []byte("#" + string(*x)), nil
+}
+
+func (x *pointerCode) UnmarshalBinary(b []byte) error {
+	v, ok := strings.CutPrefix(string(b), "#")
+	if !ok {
+		return errTest
+	}
+	*x = pointerCode(v)
+	return nil
+}
+
+// pointerFullName is a variable size struct,
+// which implements the text encoding interfaces with pointer receivers.
+type pointerFullName struct {
+	first, last string
+}
+
+func (x *pointerFullName) MarshalText() ([]byte, error) {
+	return []byte(x.first + " " + x.last), nil
+}
+
+func (x *pointerFullName) UnmarshalText(b []byte) error {
+	x.first, x.last, _ = strings.Cut(string(b), " ")
+	return nil
+}
+
+// textOnly is a variable size struct, which can only be marshaled as text.
+type textOnly struct {
+	s string
+}
+
+func (x textOnly) MarshalText() ([]byte, error) {
+	return []byte(x.s), nil
+}
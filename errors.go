@@ -0,0 +1,41 @@
+package set
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by the functions and methods of this package
+// for invalid data, invalid journals, hash collisions and unsupported element types.
+// Returned errors wrap them with more details and can be checked with [errors.Is].
+// Errors from decoding elements, e.g. by their UnmarshalJSON methods, are wrapped as well and stay reachable.
+//
+// Errors from encoding elements, e.g. by their MarshalJSON methods,
+// and from readers, writers and callbacks, e.g. the scan function of [CollectRowsFunc], are returned as they are.
+// Errors of the template functions of [FuncMap] for invalid arguments do not wrap any of these errors.
+var (
+	// ErrDuplicateElement is returned when decoding data with duplicate elements,
+	// which is rejected e.g. by [Strict].
+	ErrDuplicateElement = errors.New("set: duplicate element")
+
+	// ErrHashCollision is returned by [Freeze] when two elements have the same hash.
+	ErrHashCollision = errors.New("set: hash collision")
+
+	// ErrInvalidEncoding is returned when decoding invalid data,
+	// e.g. by [Set.UnmarshalBinary], [Set.UnmarshalJSON] and [LoadFrozenSet].
+	ErrInvalidEncoding = errors.New("set: invalid encoding")
+
+	// ErrInvalidJournal is returned for invalid journal entries, e.g. by [LoadJournal].
+	ErrInvalidJournal = errors.New("set: invalid journal")
+
+	// ErrUnsupportedType is returned when encoding or decoding elements of unsupported types,
+	// e.g. by [Set.MarshalBinary].
+	ErrUnsupportedType = errors.New("set: unsupported element type")
+)
+
+var (
+	errInvalidSet            = fmt.Errorf("%w of set", ErrInvalidEncoding)
+	errInvalidCountMinSketch = fmt.Errorf("%w of count-min sketch", ErrInvalidEncoding)
+	errInvalidIBLT           = fmt.Errorf("%w of IBLT", ErrInvalidEncoding)
+	errInvalidFrozenSet      = fmt.Errorf("%w of frozen set", ErrInvalidEncoding)
+)
@@ -0,0 +1,109 @@
+package set_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestErrors(t *testing.T) {
+	collide := func(int) uint64 {
+		return 1
+	}
+	cases := []struct {
+		name string
+		fn   func() error
+		want error
+	}{
+		{"invalid binary set", func() error {
+			return new(set.Set[int]).UnmarshalBinary([]byte{0xff})
+		}, set.ErrInvalidEncoding},
+		{"invalid IBLT", func() error {
+			return new(set.IBLT).UnmarshalBinary([]byte{0xff})
+		}, set.ErrInvalidEncoding},
+		{"invalid frozen set", func() error {
+			_, err := set.LoadFrozenSet([]byte{0xff}, hashInt)
+			return err
+		}, set.ErrInvalidEncoding},
+		{"invalid JSON set", func() error {
+			return json.Unmarshal([]byte(`{}`), new(set.Set[int]))
+		}, set.ErrInvalidEncoding},
+		{"invalid JSON element", func() error {
+			return json.Unmarshal([]byte(`["a"]`), new(set.Set[int]))
+		}, set.ErrInvalidEncoding},
+		{"truncated JSON array", func() error {
+			_, err := set.DecodeJSON[int](json.NewDecoder(strings.NewReader(`[1,`)))
+			return err
+		}, set.ErrInvalidEncoding},
+		{"empty JSON input", func() error {
+			_, err := set.DecodeJSON[int](json.NewDecoder(strings.NewReader("")))
+			return err
+		}, set.ErrInvalidEncoding},
+		{"missing closing bracket", func() error {
+			_, err := set.DecodeJSON[int](json.NewDecoder(strings.NewReader(`[1`)))
+			return err
+		}, set.ErrInvalidEncoding},
+		{"invalid lenient JSON value", func() error {
+			return json.Unmarshal([]byte(`"a"`), new(set.Lenient[int]))
+		}, set.ErrInvalidEncoding},
+		{"invalid NDJSON value", func() error {
+			_, err := set.CollectNDJSON[int](strings.NewReader("1\n\"a\"\n"))
+			return err
+		}, set.ErrInvalidEncoding},
+		{"invalid binary element", func() error {
+			return new(set.Set[fullName]).UnmarshalBinary([]byte{1, 1, 'a'})
+		}, set.ErrInvalidEncoding},
+		{"duplicate JSON element", func() error {
+			return json.Unmarshal([]byte(`[1,1]`), new(set.Strict[int]))
+		}, set.ErrDuplicateElement},
+		{"hash collision", func() error {
+			_, err := set.Freeze(set.Of(1, 2), collide)
+			return err
+		}, set.ErrHashCollision},
+		{"invalid journal entry", func() error {
+			_, err := set.LoadJournal([]set.JournalEntry[int]{{Seq: 2, Op: set.JournalAdd}})
+			return err
+		}, set.ErrInvalidJournal},
+		{"invalid journal operation", func() error {
+			var op set.JournalOp
+			return op.UnmarshalText([]byte("x"))
+		}, set.ErrInvalidJournal},
+		{"unsupported encoding type", func() error {
+			_, err := set.Of[any](1).MarshalBinary()
+			return err
+		}, set.ErrUnsupportedType},
+		{"unsupported decoding type", func() error {
+			return new(set.Set[*int]).UnmarshalBinary([]byte{1, 1})
+		}, set.ErrUnsupportedType},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.fn(); !errors.Is(err, tc.want) {
+				t.Errorf("got %q, wanted %q", err, tc.want)
+			}
+		})
+	}
+	t.Run("should keep decoding errors reachable", func(t *testing.T) {
+		err := new(set.Set[fullName]).UnmarshalBinary([]byte{1, 1, 'a'})
+		if !errors.Is(err, errTest) {
+			t.Errorf("got %q, wanted %q", err, errTest)
+		}
+		err = json.Unmarshal([]byte(`["a"]`), new(set.Set[int]))
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			t.Errorf("got %q, wanted %T", err, typeErr)
+		}
+	})
+	t.Run("should not wrap read errors", func(t *testing.T) {
+		for _, r := range []io.Reader{errReader{}, io.MultiReader(strings.NewReader("[1,"), errReader{})} {
+			_, err := set.DecodeJSON[int](json.NewDecoder(r))
+			if !errors.Is(err, errTest) || errors.Is(err, set.ErrInvalidEncoding) {
+				t.Errorf("got %q, wanted %q", err, errTest)
+			}
+		}
+	})
+}
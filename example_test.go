@@ -2,8 +2,17 @@ package set_test
 
 import (
 	"cmp"
+	"database/sql"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"maps"
+	"math"
+	"os"
 	"slices"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/ErikKalkoken/go-set"
 )
@@ -49,12 +58,119 @@ func Example() {
 	// Difference (s1 - s2): {2 7}
 }
 
+func ExampleAddMapKeys() {
+	var hosts set.Set[string]
+	for _, m := range []map[string]int{{"a": 1, "b": 2}, {"b": 3, "c": 4}} {
+		set.AddMapKeys(&hosts, m)
+	}
+	fmt.Println(hosts)
+	// Output: {a b c}
+}
+
+func ExampleAddMapValues() {
+	var owners set.Set[string]
+	set.AddMapValues(&owners, map[int]string{1: "alice", 2: "bob", 3: "alice"})
+	fmt.Println(owners)
+	// Output: {alice bob}
+}
+
+func ExampleAddNonNaN() {
+	var s set.Set[float64]
+	n := set.AddNonNaN(&s, 1, math.NaN(), 2)
+	fmt.Println(s, n)
+	// Output: {1 2} 1
+}
+
 func ExampleCollect() {
 	s := set.Collect(set.Of(1, 2, 3).All())
 	fmt.Println(s)
 	// Output: {1 2 3}
 }
 
+func ExampleCollectNDJSON() {
+	r := strings.NewReader("\"alpha\"\n\"bravo\"\n\"alpha\"\n")
+	s, err := set.CollectNDJSON[string](r)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s)
+	// Output: {alpha bravo}
+}
+
+func ExampleCollectRows() {
+	var db *sql.DB // opened with a database driver
+	rows, err := db.Query("SELECT DISTINCT user_id FROM sessions")
+	if err != nil {
+		panic(err)
+	}
+	active, err := set.CollectRows[int64](rows)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(active.Size())
+}
+
+func ExampleComplement() {
+	shards := set.Range(0, 8, 1)
+	covered := set.Of(0, 1, 2, 4, 5, 7)
+	fmt.Println(set.Complement(covered, shards))
+	// Output: {3 6}
+}
+
+func ExampleCompactSet() {
+	type UUID [16]byte
+	a := UUID{0x01, 0x02}
+	b := UUID{0x03, 0x04}
+	s := set.NewCompactSet[UUID](2)
+	s.Add(a)
+	fmt.Println(s.Contains(a))
+	fmt.Println(s.Contains(b))
+	fmt.Println(s.Size())
+	// Output:
+	// true
+	// false
+	// 1
+}
+
+func ExampleCountMinSketch() {
+	hash := func(s string) uint64 {
+		h := fnv.New64a()
+		h.Write([]byte(s))
+		return h.Sum64()
+	}
+	cms := set.NewCountMinSketch(1000, 5, hash)
+	cms.Add("alice", "bob", "alice")
+	fmt.Println(cms.EstimateCount("alice"))
+	fmt.Println(cms.EstimateCount("bob"))
+	// Output:
+	// 2
+	// 1
+}
+
+func ExampleDecodeJSON() {
+	r := strings.NewReader(`[3, 1, 2, 3]`)
+	s, err := set.DecodeJSON[int](json.NewDecoder(r))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s)
+	// Output: {1 2 3}
+}
+
+func ExampleDerefSet() {
+	type Config struct {
+		Name string
+	}
+	configs := []*Config{{"a"}, {"b"}, {"a"}}
+	var s set.DerefSet[Config]
+	s.Add(configs...)
+	fmt.Println(s.Size())
+	fmt.Println(s.Contains(&Config{"b"}))
+	// Output:
+	// 2
+	// true
+}
+
 func ExampleDifference() {
 	s1 := set.Of(1, 2)
 	s2 := set.Of(2, 3)
@@ -62,6 +178,54 @@ func ExampleDifference() {
 	// Output: {1}
 }
 
+func ExampleDistinctBy() {
+	type event struct {
+		ID   int
+		Data []byte
+	}
+	events := []event{{1, []byte("a")}, {2, []byte("b")}, {1, []byte("c")}}
+	key := func(e event) int {
+		return e.ID
+	}
+	for e := range set.DistinctBy(slices.Values(events), key) {
+		fmt.Println(e.ID, string(e.Data))
+	}
+	// Output:
+	// 1 a
+	// 2 b
+}
+
+func ExampleIntSet() {
+	var s set.IntSet[int]
+	s.Add(1, 1000)
+	fmt.Println(s.IsDense())
+	for i := range 1000 {
+		s.Add(i)
+	}
+	fmt.Println(s.IsDense())
+	fmt.Println(s.Size())
+	// Output:
+	// false
+	// true
+	// 1001
+}
+
+func ExampleInterner() {
+	hash := func(s string) uint64 {
+		h := fnv.New64a()
+		h.Write([]byte(s))
+		return h.Sum64()
+	}
+	in := set.NewInterner(hash)
+	a, _ := in.Intern(set.Of("red", "blue"))
+	b, _ := in.Intern(set.Of("blue", "red"))
+	fmt.Println(a == b)
+	fmt.Println(in.Size())
+	// Output:
+	// true
+	// 1
+}
+
 func ExampleIntersection() {
 	s1 := set.Of(1, 2)
 	s2 := set.Of(2, 3)
@@ -69,12 +233,210 @@ func ExampleIntersection() {
 	// Output: {2}
 }
 
+func ExampleEliasFanoSet() {
+	postings := set.EliasFanoOf(3, 7, 12, 100, 101, 1_000_000)
+	fmt.Println(postings.Contains(100), postings.Contains(99))
+	fmt.Println(postings.Rank(100))
+	fmt.Println(postings.Select(2))
+	// Output:
+	// true false
+	// 3
+	// 12
+}
+
+func ExampleEqualApprox() {
+	x, y := 0.1, 0.2
+	a := set.Of(x+y, 1.0)
+	b := set.Of(0.3, 1.0)
+	fmt.Println(a.Equal(b))
+	fmt.Println(set.EqualApprox(a, b, 1e-9))
+	// Output:
+	// false
+	// true
+}
+
+func ExampleFingerprintSet() {
+	var seen set.FingerprintSet
+	seen.Add("https://example.com/a")
+	fmt.Println(seen.Contains("https://example.com/a"))
+	fmt.Println(seen.Contains("https://example.com/b"))
+	// Output:
+	// true
+	// false
+}
+
+func ExampleFreeze() {
+	hash := func(s string) uint64 {
+		h := fnv.New64a()
+		h.Write([]byte(s))
+		return h.Sum64()
+	}
+	keywords, err := set.Freeze(set.Of("break", "case", "chan", "const", "continue"), hash)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(keywords.Contains("chan"), keywords.Contains("goto"))
+
+	// the encoded set can be embedded with go:embed and loaded at startup
+	data, err := keywords.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	loaded, err := set.LoadFrozenSet(data, hash)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(loaded)
+	// Output:
+	// true false
+	// {break case chan const continue}
+}
+
+func ExampleFromMap() {
+	// StringSet is a set type as used by other packages.
+	type StringSet map[string]struct{}
+	legacy := StringSet{"alpha": {}, "bravo": {}}
+	s := set.FromMap(legacy)
+	s.Add("charlie")
+	legacy = set.ToMap[StringSet](s)
+	fmt.Println(len(legacy))
+	// Output: 3
+}
+
+func ExampleFromMapBool() {
+	enabled := map[string]bool{"search": true, "export": false, "beta": true}
+	fmt.Println(set.FromMapBool(enabled))
+	// Output: {beta search}
+}
+
+func ExampleFuncMap() {
+	tmpl := template.Must(template.New("tags").Funcs(set.FuncMap()).Parse(
+		`{{range sortedList .}}<{{.}}>{{end}} {{join . ", "}} {{contains . "go"}}`,
+	))
+	err := tmpl.Execute(os.Stdout, set.Of("go", "db", "api"))
+	if err != nil {
+		panic(err)
+	}
+	// Output: <api><db><go> api, db, go true
+}
+
+func ExampleHashedSet() {
+	hash := func(s string) uint64 {
+		h := fnv.New64a()
+		h.Write([]byte(s))
+		return h.Sum64()
+	}
+	desired := set.NewHashedSet(hash, "a", "b", "c")
+	actual := set.NewHashedSet(hash, "a", "b", "x")
+	fmt.Println(desired.Equal(actual))
+	actual.Delete("x")
+	actual.Add("c")
+	fmt.Println(desired.Equal(actual))
+	// Output:
+	// false
+	// true
+}
+
+func ExampleIBLT() {
+	hash := func(s string) uint64 {
+		h := fnv.New64a()
+		h.Write([]byte(s))
+		return h.Sum64()
+	}
+	local := set.Of("alpha", "bravo", "charlie")
+	remote := set.Of("alpha", "bravo", "delta")
+
+	// The remote party sends its IBLT, which is subtracted from the local IBLT.
+	t := set.ToIBLT(local, 10, hash)
+	t.Subtract(set.ToIBLT(remote, 10, hash))
+	onlyLocal, onlyRemote, ok := t.Decode()
+	fmt.Println(ok)
+	fmt.Println(set.ElementsWithHash(local, onlyLocal, hash))
+	fmt.Println(set.ElementsWithHash(remote, onlyRemote, hash))
+	// Output:
+	// true
+	// {charlie}
+	// {delta}
+}
+
+func ExampleJournal() {
+	var members set.Journal[string]
+	members.Add("alice", "bob")
+	members.Delete("alice")
+	members.Add("carol")
+	for _, e := range members.Entries() {
+		fmt.Println(e.Seq, e.Op, e.Element)
+	}
+	fmt.Println(members.SetAt(2))
+	fmt.Println(members.Set())
+	// Output:
+	// 1 add alice
+	// 2 add bob
+	// 3 delete alice
+	// 4 add carol
+	// {alice bob}
+	// {bob carol}
+}
+
+func ExampleKeysIntersect() {
+	stock := map[string]int{"apple": 3, "banana": 0, "cherry": 7}
+	prices := map[string]float64{"apple": 0.5, "cherry": 2.0, "durian": 9.0}
+	fmt.Println(set.KeysIntersect(stock, prices))
+	// Output:
+	// {apple cherry}
+}
+
+func ExampleLenient() {
+	var payload struct {
+		Tags set.Lenient[string] `json:"tags"`
+	}
+	for _, doc := range []string{`{"tags":["foo","bar"]}`, `{"tags":"foo"}`} {
+		err := json.Unmarshal([]byte(doc), &payload)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(payload.Tags)
+	}
+	// Output:
+	// {bar foo}
+	// {foo}
+}
+
+func ExampleMakePatch() {
+	old := set.Of(1, 2, 3)
+	new := set.Of(2, 3, 4)
+	p := set.MakePatch(old, new)
+	fmt.Println(p.Add, p.Remove)
+	set.ApplyPatch(&old, p)
+	fmt.Println(old)
+	// Output:
+	// {4} {1}
+	// {2 3 4}
+}
+
 func ExampleMax() {
 	s := set.Of(1, 2)
 	fmt.Println(set.Max(s))
 	// Output: 2
 }
 
+func ExampleMaxBy() {
+	type event struct {
+		name      string
+		createdAt time.Time
+	}
+	events := set.Of(
+		event{"deploy", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		event{"rollback", time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)},
+		event{"release", time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)},
+	)
+	latest := set.MaxBy(events, func(e event) int64 {
+		return e.createdAt.UnixNano()
+	})
+	fmt.Println(latest.name)
+	// Output: rollback
+}
+
 func ExampleMaxFunc() {
 	s := set.Of(1, 2)
 	fmt.Println(set.MaxFunc(s, func(a, b int) int {
@@ -83,6 +445,15 @@ func ExampleMaxFunc() {
 	// Output: 2
 }
 
+func ExampleMergeSorted() {
+	shard1 := slices.Values([]int{1, 4, 9})
+	shard2 := slices.Values([]int{2, 4, 8, 9})
+	for v := range set.MergeSorted(shard1, shard2) {
+		fmt.Print(v, " ")
+	}
+	// Output: 1 2 4 8 9
+}
+
 func ExampleMin() {
 	s := set.Of(1, 2)
 	fmt.Println(set.Min(s))
@@ -97,6 +468,130 @@ func ExampleMinFunc() {
 	// Output: 1
 }
 
+func ExamplePool() {
+	var pool set.Pool[string]
+
+	countDistinct := func(words []string) int {
+		seen := pool.Get()
+		defer pool.Put(seen)
+		for _, w := range words {
+			seen.Add(w)
+		}
+		return seen.Size()
+	}
+
+	fmt.Println(countDistinct([]string{"a", "b", "a"}))
+	fmt.Println(countDistinct([]string{"x", "y", "z"}))
+	// Output:
+	// 2
+	// 3
+}
+
+func ExamplePrioritySet() {
+	var frontier set.PrioritySet[string, int]
+	frontier.Add("/", 10)
+	frontier.Add("/about", 1)
+	frontier.Add("/", 5) // already queued
+	frontier.Add("/blog", 3)
+	frontier.UpdatePriority("/about", 7)
+	for frontier.Size() > 0 {
+		url, prio, _ := frontier.PopHighest()
+		fmt.Println(url, prio)
+	}
+	// Output:
+	// / 10
+	// /about 7
+	// /blog 3
+}
+
+func ExampleRange() {
+	shards := set.Range(0, 64, 1)
+	fmt.Println(shards.Size())
+	ports := set.Range(8000, 8100, 25)
+	fmt.Println(ports)
+	// Output:
+	// 64
+	// {8000 8025 8050 8075}
+}
+
+func ExampleSortedSet() {
+	ids := set.SortedOf(42, 7, 13, 99, 7)
+	active := set.SortedOf(7, 8, 42)
+	fmt.Println(ids)
+	fmt.Println(ids.Contains(13))
+	fmt.Println(ids.Intersection(active))
+	fmt.Println(ids.Union(active))
+	fmt.Println(ids.Difference(active))
+	// Output:
+	// {7 13 42 99}
+	// true
+	// {7 42}
+	// {7 8 13 42 99}
+	// {13 99}
+}
+
+func ExampleSpillSet() {
+	// keep at most 2 elements in memory and spill the rest to temporary files
+	seen, err := set.CollectSpill(slices.Values([]string{"c", "a", "b", "a", "d"}), "", 2)
+	if err != nil {
+		panic(err)
+	}
+	defer seen.Close()
+	for v := range seen.All() {
+		fmt.Print(v, " ")
+	}
+	if err := seen.Err(); err != nil {
+		panic(err)
+	}
+	// Output: a b c d
+}
+
+func ExampleSplit() {
+	users := set.Of("ann", "bob", "cat", "dan", "eve")
+	cohorts := set.Split(users, 2, nil)
+	fmt.Println(cohorts[0].Size(), cohorts[1].Size())
+	fmt.Println(set.Union(cohorts...).Equal(users))
+	// Output:
+	// 3 2
+	// true
+}
+
+func ExampleSubMap() {
+	stock := map[string]int{"apple": 3, "banana": 0, "cherry": 7}
+	fmt.Println(set.SubMap(stock, set.Of("apple", "banana")))
+	// Output:
+	// map[apple:3 banana:0]
+}
+
+func ExampleTaggedUnion() {
+	local := set.Of("a", "b")
+	remote := set.Of("b", "c")
+	tags := set.TaggedUnion(local, remote)
+	for _, v := range slices.Sorted(maps.Keys(tags)) {
+		fmt.Println(v, tags[v])
+	}
+	// Output:
+	// a [0]
+	// b [0 1]
+	// c [1]
+}
+
+func ExampleToMapBool() {
+	m := set.ToMapBool(set.Of("a", "b"))
+	fmt.Println(m)
+	// Output: map[a:true b:true]
+}
+
+func ExampleTryMax() {
+	latest, ok := set.TryMax(set.Of[int]())
+	fmt.Println(latest, ok)
+	latest, ok = set.TryMax(set.Of(2024, 2026, 2025))
+	fmt.Println(latest, ok)
+	// Output:
+	// 0 false
+	// 2026 true
+}
+
 func ExampleUnion() {
 	s1 := set.Of(1, 2)
 	s2 := set.Of(2, 3)
@@ -104,6 +599,14 @@ func ExampleUnion() {
 	// Output: {1 2 3}
 }
 
+func ExampleNew() {
+	ids := []int{3, 1, 4, 1, 5}
+	s := set.New[int](len(ids))
+	s.Add(ids...)
+	fmt.Println(s)
+	// Output: {1 3 4 5}
+}
+
 func ExampleOf() {
 	s1 := set.Of(1, 2, 2)
 	s2 := set.Of([]int{3, 4}...)
@@ -142,6 +645,35 @@ func ExampleSet_All() {
 	// 3
 }
 
+// Elements can be sorted in any order with [slices.SortedFunc],
+// e.g. with the CompareString method of a collate.Collator from golang.org/x/text/collate
+// for sorting strings for a language.
+func ExampleSet_All_sortedFunc() {
+	s := set.Of("eclair", "Zebra", "apple", "Banana")
+	fmt.Println(slices.Sorted(s.All()))
+	fmt.Println(slices.SortedFunc(s.All(), func(a, b string) int {
+		return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+	}))
+	// Output:
+	// [Banana Zebra apple eclair]
+	// [apple Banana eclair Zebra]
+}
+
+func ExampleSet_AppendText() {
+	buf := make([]byte, 0, 64)
+	for _, s := range []set.Set[int]{set.Of(1), set.Of(2)} {
+		var err error
+		buf, err = s.AppendText(buf[:0])
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(string(buf))
+	}
+	// Output:
+	// [1]
+	// [2]
+}
+
 func ExampleSet_Clear() {
 	s := set.Of(1, 2)
 	s.Clear()
@@ -176,6 +708,15 @@ func ExampleSet_ContainsAll() {
 	// false
 }
 
+func ExampleSet_ContainsAllOf() {
+	s := set.Of(1, 2)
+	fmt.Println(s.ContainsAllOf(1, 2))
+	fmt.Println(s.ContainsAllOf(1, 2, 3))
+	// Output:
+	// true
+	// false
+}
+
 func ExampleSet_ContainsAny() {
 	s := set.Of(1, 2)
 	fmt.Println(s.ContainsAny(set.Of(1).All()))
@@ -191,6 +732,15 @@ func ExampleSet_ContainsAny() {
 	// false
 }
 
+func ExampleSet_ContainsAnyOf() {
+	s := set.Of(1, 2)
+	fmt.Println(s.ContainsAnyOf(2, 3))
+	fmt.Println(s.ContainsAnyOf(3, 4))
+	// Output:
+	// true
+	// false
+}
+
 func ExampleSet_ContainsFunc() {
 	s := set.Of(1, 2)
 	fmt.Println(s.ContainsFunc(func(x int) bool {
@@ -204,6 +754,13 @@ func ExampleSet_ContainsFunc() {
 	// false
 }
 
+func ExampleSet_CountContained() {
+	known := set.Of("a", "b", "c")
+	batch := []string{"a", "c", "c", "x"}
+	fmt.Println(known.CountContained(slices.Values(batch)))
+	// Output: 2
+}
+
 func ExampleSet_Delete() {
 	s := set.Of(1, 2)
 	s.Delete(2)
@@ -236,6 +793,19 @@ func ExampleSet_Equal() {
 	// false
 }
 
+func ExampleSet_IsEmpty() {
+	var s1 set.Set[int]
+	s2 := set.Of[int]()
+	s3 := set.Of(1)
+	fmt.Println(s1.IsEmpty())
+	fmt.Println(s2.IsEmpty())
+	fmt.Println(s3.IsEmpty())
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleSet_IsZero() {
 	var s1 set.Set[int]
 	s2 := set.Of[int]()
@@ -246,6 +816,23 @@ func ExampleSet_IsZero() {
 	// false
 }
 
+func ExampleSet_JSONSchema() {
+	// register the schema of a set as component of an OpenAPI specification
+	spec := map[string]any{
+		"components": map[string]any{
+			"schemas": map[string]any{
+				"Tags": set.Set[string]{}.JSONSchema(),
+			},
+		},
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(b))
+	// Output: {"components":{"schemas":{"Tags":{"items":{"type":"string"},"type":["array","null"],"uniqueItems":true}}}}
+}
+
 func ExampleSet_Pop() {
 	s := set.Of(1)
 	v, ok := s.Pop()
@@ -257,6 +844,24 @@ func ExampleSet_Pop() {
 	// false
 }
 
+func ExampleSet_Scan() {
+	type user struct {
+		ID    int64
+		Roles set.Set[string]
+	}
+	var db *sql.DB // opened with a database driver
+	u := user{ID: 1, Roles: set.Of("admin")}
+	// sets are stored as JSON
+	if _, err := db.Exec("INSERT INTO users (id, roles) VALUES (?, ?)", u.ID, u.Roles); err != nil {
+		panic(err)
+	}
+	var roles set.Set[string]
+	if err := db.QueryRow("SELECT roles FROM users WHERE id = ?", u.ID).Scan(&roles); err != nil {
+		panic(err)
+	}
+	fmt.Println(roles)
+}
+
 func ExampleSet_Size() {
 	s := set.Of(1, 2, 3)
 	fmt.Println(s.Size())
@@ -268,3 +873,51 @@ func ExampleSet_String() {
 	fmt.Println(s)
 	// Unordered output: {1 2 3}
 }
+
+func ExampleNonNull() {
+	var payload struct {
+		Tags set.NonNull[string] `json:"tags"`
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(b))
+	// Output:
+	// {"tags":[]}
+}
+
+func ExampleStrict() {
+	var payload struct {
+		IDs set.Strict[int] `json:"ids"`
+	}
+	err := json.Unmarshal([]byte(`{"ids":[1,2,1]}`), &payload)
+	fmt.Println(err)
+	// Output: set: duplicate element in JSON array: 1
+}
+
+func ExampleTimedSet() {
+	lastRun := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var s set.TimedSet[string]
+	s.AddAt(lastRun.Add(-time.Hour), "a.txt")
+	s.AddAt(lastRun.Add(time.Minute), "b.txt")
+	fmt.Println(s.AddedSince(lastRun))
+	fmt.Println(s.OlderThan(lastRun))
+	// Output:
+	// {b.txt}
+	// {a.txt}
+}
+
+func ExampleWindow() {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	w := set.NewWindow[string](0, time.Minute)
+	w.Add("alice", start)
+	w.Add("bob", start.Add(20*time.Second))
+	w.Add("alice", start.Add(40*time.Second))
+	fmt.Println(w.DistinctCount())
+	w.Advance(start.Add(70 * time.Second))
+	fmt.Println(w.AsSet())
+	// Output:
+	// 2
+	// {alice bob}
+}
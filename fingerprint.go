@@ -0,0 +1,70 @@
+package set
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+)
+
+// A FingerprintSet is a memory efficient set of strings,
+// which only stores a 64-bit fingerprint of each string.
+//
+// Membership checks can report false positives when two strings have the same fingerprint.
+// The probability of a false positive for a string, which has not been added,
+// is about n/2^64 for a set with n elements, e.g. about 5e-12 for 100 million elements.
+// There are no false negatives.
+//
+// Since the strings are not stored, they can not be retrieved from the set.
+// Fingerprints are seeded randomly for each set and are therefore
+// not stable across sets or processes.
+//
+// The zero value of a FingerprintSet is an empty set ready to use.
+// FingerprintSet is not safe for concurrent use.
+type FingerprintSet struct {
+	fps  CompactSet[[8]byte]
+	seed maphash.Seed
+}
+
+// NewFingerprintSet returns a new empty fingerprint set with space for at least capacity elements.
+func NewFingerprintSet(capacity int) *FingerprintSet {
+	s := &FingerprintSet{seed: maphash.MakeSeed()}
+	s.fps.resize(capacity)
+	return s
+}
+
+// Add adds strings v to set s.
+func (s *FingerprintSet) Add(v ...string) {
+	for _, x := range v {
+		s.fps.Add(s.fingerprint(x))
+	}
+}
+
+// Contains reports whether string v is probably in set s.
+func (s *FingerprintSet) Contains(v string) bool {
+	return s.fps.Contains(s.fingerprint(v))
+}
+
+// Delete removes strings v from set s.
+// It returns the number of deleted fingerprints.
+// Note that deleting a string also deletes all other strings with the same fingerprint.
+func (s *FingerprintSet) Delete(v ...string) int {
+	var c int
+	for _, x := range v {
+		c += s.fps.Delete(s.fingerprint(x))
+	}
+	return c
+}
+
+// Size returns the number of fingerprints in set s.
+// This can be less than the number of distinct strings added when fingerprints collide.
+func (s *FingerprintSet) Size() int {
+	return s.fps.Size()
+}
+
+func (s *FingerprintSet) fingerprint(v string) [8]byte {
+	if s.seed == (maphash.Seed{}) {
+		s.seed = maphash.MakeSeed()
+	}
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], maphash.String(s.seed, v))
+	return b
+}
@@ -0,0 +1,50 @@
+package set_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestFingerprintSet(t *testing.T) {
+	t.Run("can add, check and delete strings", func(t *testing.T) {
+		var s set.FingerprintSet
+		s.Add("https://a.example", "https://b.example", "https://a.example")
+		if !s.Contains("https://a.example") || s.Contains("https://c.example") {
+			t.Errorf("contains reported wrong result")
+		}
+		if s.Size() != 2 {
+			t.Errorf("got %v, wanted 2", s.Size())
+		}
+		if got := s.Delete("https://a.example", "https://c.example"); got != 1 {
+			t.Errorf("got %v, wanted 1", got)
+		}
+		if s.Contains("https://a.example") || s.Size() != 1 {
+			t.Errorf("string was not deleted")
+		}
+	})
+	t.Run("zero set", func(t *testing.T) {
+		var s set.FingerprintSet
+		if s.Contains("") || s.Size() != 0 || s.Delete("") != 0 {
+			t.Errorf("zero set should be empty")
+		}
+	})
+	t.Run("should not report false negatives", func(t *testing.T) {
+		s := set.NewFingerprintSet(10_000)
+		for i := range 10_000 {
+			s.Add(fmt.Sprintf("https://example.com/%d", i))
+		}
+		for i := range 10_000 {
+			if !s.Contains(fmt.Sprintf("https://example.com/%d", i)) {
+				t.Fatalf("%d: got false, wanted true", i)
+			}
+		}
+		if s.Contains("https://example.com/x") {
+			t.Errorf("got true, wanted false")
+		}
+		if s.Size() != 10_000 {
+			t.Errorf("got %v, wanted 10000", s.Size())
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package set
+
+import (
+	"slices"
+)
+
+type float interface {
+	~float32 | ~float64
+}
+
+// AddNonNaN adds the elements v, which are not NaN, to set s.
+// It returns the number of NaN values, which have been rejected.
+//
+// Since NaN is not equal to itself, each NaN added to a set is a distinct element,
+// which can never be found with [Set.Contains] or removed with [Set.Delete].
+// AddNonNaN therefore ensures a set of floats never contains NaN.
+func AddNonNaN[E float](s *Set[E], v ...E) int {
+	var c int
+	for _, x := range v {
+		if x != x {
+			c++
+			continue
+		}
+		s.Add(x)
+	}
+	return c
+}
+
+// ContainsNaN reports whether set s contains at least one NaN element.
+func ContainsNaN[E float](s Set[E]) bool {
+	return s.ContainsFunc(func(x E) bool {
+		return x != x
+	})
+}
+
+// DeleteNaN deletes all NaN elements from set s.
+// It returns the number of deleted elements.
+//
+// NaN elements can not be deleted with [Set.Delete] or [Set.DeleteFunc],
+// because a NaN key can never be found in the underlying map.
+func DeleteNaN[E float](s Set[E]) int {
+	if !ContainsNaN(s) {
+		return 0
+	}
+	ln := len(s.m)
+	var keep []E
+	for x := range s.m {
+		if x == x {
+			keep = append(keep, x)
+		}
+	}
+	p := beginWrite(s.m, "DeleteNaN")
+	clear(s.m)
+	for _, x := range keep {
+		s.m[x] = struct{}{}
+	}
+	endWrite(p)
+	return ln - len(s.m)
+}
+
+// EqualApprox reports whether the sets a and b are equal,
+// when elements which differ by at most eps are considered equal.
+//
+// The sets must have the same size and the elements are compared pairwise in ascending order.
+// NaN elements are considered equal to each other.
+func EqualApprox[E float](a, b Set[E], eps E) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+	x := slices.Sorted(a.All())
+	y := slices.Sorted(b.All())
+	for i := range x {
+		v, w := x[i], y[i]
+		if v != v || w != w {
+			if v == v || w == w {
+				return false
+			}
+			continue
+		}
+		if v-w > eps || w-v > eps {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package set_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestAddNonNaN(t *testing.T) {
+	nan := math.NaN()
+	var s set.Set[float64]
+	got := set.AddNonNaN(&s, 1, nan, 2, nan)
+	if got != 2 {
+		t.Errorf("got %v, wanted 2", got)
+	}
+	want := set.Of(1.0, 2.0)
+	if !s.Equal(want) {
+		t.Errorf("got %v, wanted %v", s, want)
+	}
+}
+
+func TestContainsNaN(t *testing.T) {
+	cases := []struct {
+		name string
+		s    set.Set[float64]
+		want bool
+	}{
+		{"contains NaN", set.Of(1, math.NaN()), true},
+		{"does not contain NaN", set.Of(1, math.Inf(1)), false},
+		{"empty", set.Of[float64](), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := set.ContainsNaN(tc.s); got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeleteNaN(t *testing.T) {
+	cases := []struct {
+		name      string
+		s         set.Set[float64]
+		wantSet   set.Set[float64]
+		wantCount int
+	}{
+		{"multiple NaN", set.Of(1, math.NaN(), 2, math.NaN()), set.Of(1.0, 2.0), 2},
+		{"only NaN", set.Of(math.NaN()), set.Of[float64](), 1},
+		{"no NaN", set.Of(1.0), set.Of(1.0), 0},
+		{"zero set", set.Set[float64]{}, set.Set[float64]{}, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := set.DeleteNaN(tc.s)
+			if got != tc.wantCount {
+				t.Errorf("got %v, wanted %v", got, tc.wantCount)
+			}
+			if !tc.s.Equal(tc.wantSet) {
+				t.Errorf("got %v, wanted %v", tc.s, tc.wantSet)
+			}
+		})
+	}
+}
+
+func TestEqualApprox(t *testing.T) {
+	nan := math.NaN()
+	cases := []struct {
+		name string
+		a    set.Set[float64]
+		b    set.Set[float64]
+		eps  float64
+		want bool
+	}{
+		{"equal", set.Of(1.0, 2.0), set.Of(1.0, 2.0), 0, true},
+		{"within epsilon", set.Of(1.0, 2.0), set.Of(1.05, 1.95), 0.1, true},
+		{"outside epsilon", set.Of(1.0, 2.0), set.Of(1.0, 2.2), 0.1, false},
+		{"outside epsilon negative", set.Of(1.0, 2.0), set.Of(1.0, 1.8), 0.1, false},
+		{"different size", set.Of(1.0, 2.0), set.Of(1.0), 0.1, false},
+		{"NaN on both sides", set.Of(1.0, nan), set.Of(1.0, nan), 0, true},
+		{"NaN on one side", set.Of(1.0, nan), set.Of(1.0, 2.0), 0, false},
+		{"NaN on other side", set.Of(1.0, 2.0), set.Of(1.0, nan), 0, false},
+		{"empty", set.Of[float64](), set.Set[float64]{}, 0, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := set.EqualApprox(tc.a, tc.b, tc.eps); got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
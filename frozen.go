@@ -0,0 +1,225 @@
+package set
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// frozenBucketSize is the average number of elements per bucket of a FrozenSet.
+const frozenBucketSize = 4
+
+// A FrozenSet is an immutable set, which uses a minimal perfect hash function to find elements.
+//
+// The elements are stored in a single slice without any empty slots.
+// Each element is assigned to a bucket and each bucket has a seed,
+// which has been chosen so that the elements of all buckets are mapped to different slots.
+// Looking up an element takes constant time with one bucket lookup and one comparison,
+// and the memory overhead is about one byte per element.
+//
+// A FrozenSet is created from a set with [Freeze]
+// and can be encoded with [FrozenSet.MarshalBinary] and loaded again with [LoadFrozenSet],
+// e.g. for embedding large sets into binaries without needing to build them at startup.
+// Elements are encoded as described for [Set.AppendBinary].
+// For loading an encoded set the hash function must return the same hashes as when it was frozen,
+// so it needs to be deterministic, e.g. one based on hash/fnv.
+//
+// The zero value of a FrozenSet is an empty set ready to use.
+// A FrozenSet is safe for concurrent use, because it can not be modified.
+type FrozenSet[E comparable] struct {
+	slots []E
+	seeds []uint32 // seed for each bucket
+	hash  func(E) uint64
+}
+
+// Freeze returns a new frozen set with the elements of set s,
+// which uses the function hash to hash elements.
+// It returns an error if two elements have the same hash.
+//
+// Building a frozen set takes linear time in the number of elements on average.
+func Freeze[E comparable](s Set[E], hash func(E) uint64) (*FrozenSet[E], error) {
+	f := &FrozenSet[E]{hash: hash}
+	if s.Size() == 0 {
+		return f, nil
+	}
+	type item struct {
+		h uint64
+		v E
+	}
+	items := make([]item, 0, s.Size())
+	for v := range s.All() {
+		items = append(items, item{mix64(hash(v)), v})
+	}
+	nb := (len(items) + frozenBucketSize - 1) / frozenBucketSize
+	// sort items by bucket, so that the items of each bucket are next to each other
+	slices.SortFunc(items, func(a, b item) int {
+		return cmp.Or(cmp.Compare(frozenBucket(a.h, nb), frozenBucket(b.h, nb)), cmp.Compare(a.h, b.h))
+	})
+	var buckets [][]item
+	for i := 0; i < len(items); {
+		j := i + 1
+		for j < len(items) && frozenBucket(items[j].h, nb) == frozenBucket(items[i].h, nb) {
+			if items[j].h == items[j-1].h {
+				return nil, fmt.Errorf("%w: elements %v and %v have the same hash", ErrHashCollision, items[j-1].v, items[j].v)
+			}
+			j++
+		}
+		buckets = append(buckets, items[i:j])
+		i = j
+	}
+	// place large buckets first while there are still many free slots
+	slices.SortStableFunc(buckets, func(a, b []item) int {
+		return cmp.Compare(len(b), len(a))
+	})
+	f.slots = make([]E, len(items))
+	f.seeds = make([]uint32, nb)
+	used := make([]bool, len(items))
+	var slots []int
+	// place tries to place the items of a bucket with seed into free slots.
+	place := func(bucket []item, seed uint32) bool {
+		slots = slots[:0]
+		for _, it := range bucket {
+			i := frozenSlot(it.h, seed, len(items))
+			if used[i] || slices.Contains(slots, i) {
+				return false
+			}
+			slots = append(slots, i)
+		}
+		for k, i := range slots {
+			used[i] = true
+			f.slots[i] = bucket[k].v
+		}
+		return true
+	}
+	for _, bucket := range buckets {
+		// the elements have different hashes, so a seed is found after n tries on average
+		// even for the last free slot
+		seed := uint32(0)
+		for !place(bucket, seed) {
+			seed++
+		}
+		f.seeds[frozenBucket(bucket[0].h, nb)] = seed
+	}
+	return f, nil
+}
+
+// LoadFrozenSet returns the frozen set from data encoded by [FrozenSet.MarshalBinary],
+// which uses the function hash to hash elements.
+// It returns an error if the data is invalid or if hash does not return the same hashes
+// as the function used for freezing the set.
+func LoadFrozenSet[E comparable](data []byte, hash func(E) uint64) (*FrozenSet[E], error) {
+	f := &FrozenSet[E]{hash: hash}
+	if len(data) == 0 {
+		return f, nil
+	}
+	n, k := binary.Uvarint(data)
+	if k <= 0 || n == 0 || n > uint64(len(data)) { // each element needs at least one byte
+		return nil, errInvalidFrozenSet
+	}
+	b := data[k:]
+	nb := (int(n) + frozenBucketSize - 1) / frozenBucketSize
+	if len(b) < nb*4 {
+		return nil, errInvalidFrozenSet
+	}
+	f.seeds = make([]uint32, nb)
+	for i := range f.seeds {
+		f.seeds[i] = binary.LittleEndian.Uint32(b[i*4:])
+	}
+	f.slots = make([]E, 0, n)
+	b, err := readBinaryElements(b[nb*4:], n, func(v E) {
+		f.slots = append(f.slots, v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 0 {
+		return nil, errInvalidFrozenSet
+	}
+	for i, v := range f.slots {
+		if f.slot(v) != i {
+			return nil, fmt.Errorf("%w: frozen set was created with a different hash function", ErrInvalidEncoding)
+		}
+	}
+	return f, nil
+}
+
+// All returns an iterator over all elements of set s.
+//
+// Note that the order of the elements is undefined.
+func (s *FrozenSet[E]) All() iter.Seq[E] {
+	return slices.Values(s.slots)
+}
+
+// AppendBinary appends the binary encoding of set s to b and returns the extended buffer.
+// Elements are encoded as described for [Set.AppendBinary].
+// Empty sets are encoded as empty data.
+//
+// AppendBinary implements the encoding.BinaryAppender interface.
+func (s *FrozenSet[E]) AppendBinary(b []byte) ([]byte, error) {
+	if len(s.slots) == 0 {
+		return b, nil
+	}
+	b = binary.AppendUvarint(b, uint64(len(s.slots)))
+	for _, seed := range s.seeds {
+		b = binary.LittleEndian.AppendUint32(b, seed)
+	}
+	e := newBinaryEncoder[E]()
+	for _, v := range s.slots {
+		var err error
+		b, err = e.append(b, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// Contains reports whether element v is in set s.
+func (s *FrozenSet[E]) Contains(v E) bool {
+	if len(s.slots) == 0 {
+		return false
+	}
+	return s.slots[s.slot(v)] == v
+}
+
+// MarshalBinary returns the binary encoding of set s as described for [FrozenSet.AppendBinary].
+func (s *FrozenSet[E]) MarshalBinary() ([]byte, error) {
+	return s.AppendBinary(nil)
+}
+
+// Set returns a new [Set] with the elements of set s.
+func (s *FrozenSet[E]) Set() Set[E] {
+	return Of(s.slots...)
+}
+
+// Size returns the number of elements in set s. An empty set returns 0.
+func (s *FrozenSet[E]) Size() int {
+	return len(s.slots)
+}
+
+// String returns a string representation of set s.
+// Sets are printed with curly brackets and sorted, e.g. {1 2}.
+func (s *FrozenSet[E]) String() string {
+	return s.Set().String()
+}
+
+// slot returns the position of element v in the slots of set s.
+func (s *FrozenSet[E]) slot(v E) int {
+	h := mix64(s.hash(v))
+	return frozenSlot(h, s.seeds[frozenBucket(h, len(s.seeds))], len(s.slots))
+}
+
+// frozenBucket returns the bucket for hash h from nb buckets.
+func frozenBucket(h uint64, nb int) int {
+	hi, _ := bits.Mul64(h, uint64(nb))
+	return int(hi)
+}
+
+// frozenSlot returns the slot for hash h with seed from n slots.
+func frozenSlot(h uint64, seed uint32, n int) int {
+	hi, _ := bits.Mul64(mix64(h^uint64(seed)*0x9e3779b97f4a7c15), uint64(n))
+	return int(hi)
+}
@@ -0,0 +1,190 @@
+package set_test
+
+import (
+	"hash/fnv"
+	"strconv"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func hashString(v string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(v))
+	return h.Sum64()
+}
+
+func TestFreeze(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 10, 1000, 100_000} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			s := set.Collect(set.Range(0, n, 1).All())
+			f, err := set.Freeze(s, hashInt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.Size(); got != n {
+				t.Errorf("got %v, wanted %v", got, n)
+			}
+			for i := range n {
+				if !f.Contains(i) {
+					t.Fatalf("expected %v to be contained", i)
+				}
+			}
+			for _, v := range []int{-1, n, n + 1, 1 << 40} {
+				if f.Contains(v) {
+					t.Errorf("expected %v to not be contained", v)
+				}
+			}
+			if got := set.Collect(f.All()); !got.Equal(s) {
+				t.Errorf("got %v, wanted %v", got, s)
+			}
+			if got := f.Set(); !got.Equal(s) {
+				t.Errorf("got %v, wanted %v", got, s)
+			}
+		})
+	}
+	t.Run("can freeze empty sets", func(t *testing.T) {
+		f, err := set.Freeze(set.Of[string](), hashString)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Size() != 0 || f.Contains("") {
+			t.Errorf("wanted empty set, got %v", f)
+		}
+	})
+	t.Run("should return error when elements have the same hash", func(t *testing.T) {
+		collide := func(int) uint64 {
+			return 1
+		}
+		_, err := set.Freeze(set.Of(1, 2), collide)
+		if err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+	})
+	t.Run("can print sets", func(t *testing.T) {
+		f, err := set.Freeze(set.Of(2, 1), hashInt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := f.String(), "{1 2}"; got != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+}
+
+func TestFrozenSet_ZeroValue(t *testing.T) {
+	var f set.FrozenSet[int]
+	if f.Size() != 0 || f.Contains(0) || f.String() != "{}" {
+		t.Errorf("wanted empty set, got %v", &f)
+	}
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 0 {
+		t.Errorf("got %v, wanted empty data", b)
+	}
+}
+
+func TestFrozenSet_MarshalBinary(t *testing.T) {
+	t.Run("can load marshaled sets", func(t *testing.T) {
+		s := set.Of("alpha", "bravo", "charlie", "delta", "echo", "foxtrot")
+		f, err := set.Freeze(s, hashString)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := f.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		g, err := set.LoadFrozenSet(b, hashString)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := g.Set(); !got.Equal(s) {
+			t.Errorf("got %v, wanted %v", got, s)
+		}
+		for v := range s.All() {
+			if !g.Contains(v) {
+				t.Errorf("expected %v to be contained", v)
+			}
+		}
+		if g.Contains("golf") {
+			t.Errorf("expected golf to not be contained")
+		}
+	})
+	t.Run("can load sets with elements using pointer receivers", func(t *testing.T) {
+		hash := func(v pointerBinary) uint64 {
+			return uint64(v.A)<<32 | uint64(v.B)
+		}
+		s := set.Of(pointerBinary{1, 2}, pointerBinary{3, 4}, pointerBinary{5, 6})
+		f, err := set.Freeze(s, hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := f.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		g, err := set.LoadFrozenSet(b, hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := g.Set(); !got.Equal(s) {
+			t.Errorf("got %v, wanted %v", got, s)
+		}
+	})
+	t.Run("can load empty sets", func(t *testing.T) {
+		f, err := set.LoadFrozenSet(nil, hashInt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Size() != 0 || f.Contains(0) {
+			t.Errorf("wanted empty set, got %v", f)
+		}
+	})
+	t.Run("should return error when elements can not be encoded", func(t *testing.T) {
+		hash := func(errAppender) uint64 {
+			return 0
+		}
+		f, err := set.Freeze(set.Of(errAppender{}), hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.MarshalBinary(); err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+	})
+}
+
+func TestLoadFrozenSet(t *testing.T) {
+	f, err := set.Freeze(set.Of(1, 2, 3, 4, 5), hashInt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name string
+		b    []byte
+		hash func(int) uint64
+	}{
+		{"invalid count", []byte{0xff}, hashInt},
+		{"zero count", []byte{0}, hashInt},
+		{"count too large", []byte{5, 1}, hashInt},
+		{"missing seeds", valid[:5], hashInt},
+		{"invalid element", append(valid[:9:9], 0xff), hashInt},
+		{"trailing data", append(valid[:len(valid):len(valid)], 0), hashInt},
+		{"different hash", valid, func(v int) uint64 { return hashInt(v + 1) }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := set.LoadFrozenSet(tc.b, tc.hash)
+			if err == nil {
+				t.Errorf("got %q, wanted error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package grid_test
+
+import (
+	"fmt"
+
+	"github.com/ErikKalkoken/go-set/grid"
+)
+
+// Computes the next generation of a blinker in Conway's Game of Life.
+func Example() {
+	alive := grid.Of(grid.Point{0, 1}, grid.Point{1, 1}, grid.Point{2, 1})
+	next := grid.Of()
+	for p := range alive.All() {
+		for _, q := range []grid.Point{p, {p.X, p.Y - 1}, {p.X, p.Y + 1}, {p.X - 1, p.Y}, {p.X + 1, p.Y}} {
+			var n int
+			for range alive.Neighbors(q) {
+				n++
+			}
+			if n == 3 || n == 2 && alive.Contains(q) {
+				next.Add(q)
+			}
+		}
+	}
+	fmt.Println(next)
+	// Output: {(1,0) (1,1) (1,2)}
+}
+
+func ExamplePointSet_InRect() {
+	s := grid.Of(grid.Point{1, 1}, grid.Point{5, 1}, grid.Point{2, 3})
+	for p := range s.InRect(grid.Point{0, 0}, grid.Point{3, 3}) {
+		fmt.Println(p)
+	}
+	// Unordered output:
+	// (1,1)
+	// (2,3)
+}
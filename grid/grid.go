@@ -0,0 +1,198 @@
+// Package grid provides a set of points on an integer grid with spatial queries.
+//
+// A typical use case are simulations and tile maps, e.g. the living cells of Conway's Game of Life,
+// where the neighbors of points and the points in a region need to be found quickly.
+package grid
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+// A Point is a point on an integer grid.
+type Point struct {
+	X, Y int
+}
+
+// String returns a string representation of point p, e.g. (1,2).
+func (p Point) String() string {
+	return fmt.Sprintf("(%d,%d)", p.X, p.Y)
+}
+
+// neighborOffsets are the offsets of the 8 neighbors of a point.
+var neighborOffsets = [8]Point{
+	{-1, -1}, {0, -1}, {1, -1},
+	{-1, 0}, {1, 0},
+	{-1, 1}, {0, 1}, {1, 1},
+}
+
+// A PointSet is a set of points on an integer grid.
+//
+// Points are stored by row in an [set.IntSet] for each row,
+// which uses a bitset for rows with many points close to each other.
+// This makes PointSet efficient for dense regions as well as for points scattered over a large grid.
+//
+// The zero value of a PointSet is an empty set ready to use.
+// PointSet is not safe for concurrent use.
+type PointSet struct {
+	rows map[int]*set.IntSet[int]
+	n    int
+}
+
+// Of returns a new point set with the points p.
+func Of(p ...Point) *PointSet {
+	s := &PointSet{}
+	s.Add(p...)
+	return s
+}
+
+// Add adds points p to set s.
+func (s *PointSet) Add(p ...Point) {
+	if s.rows == nil {
+		s.rows = make(map[int]*set.IntSet[int])
+	}
+	for _, q := range p {
+		row, ok := s.rows[q.Y]
+		if !ok {
+			row = &set.IntSet[int]{}
+			s.rows[q.Y] = row
+		}
+		n := row.Size()
+		row.Add(q.X)
+		s.n += row.Size() - n
+	}
+}
+
+// All returns on iterator over all points of set s.
+//
+// Note that the order of the points is undefined.
+func (s *PointSet) All() iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for y, row := range s.rows {
+			for x := range row.All() {
+				if !yield(Point{x, y}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Clear removes all points from set s.
+func (s *PointSet) Clear() {
+	*s = PointSet{}
+}
+
+// Contains reports whether point p is in set s.
+func (s *PointSet) Contains(p Point) bool {
+	row, ok := s.rows[p.Y]
+	return ok && row.Contains(p.X)
+}
+
+// Delete removes points p from set s.
+// It returns the number of deleted points.
+// Points that are not found in the set are ignored.
+func (s *PointSet) Delete(p ...Point) int {
+	var c int
+	for _, q := range p {
+		row, ok := s.rows[q.Y]
+		if !ok {
+			continue
+		}
+		c += row.Delete(q.X)
+		if row.Size() == 0 {
+			delete(s.rows, q.Y)
+		}
+	}
+	s.n -= c
+	return c
+}
+
+// InRect returns an iterator over all points of set s in the rectangle
+// from min to max, including the points on its border.
+// The rectangle is empty when the coordinates of min are larger than of max.
+//
+// Note that the order of the points is undefined.
+func (s *PointSet) InRect(min, max Point) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		if min.X > max.X || min.Y > max.Y {
+			return
+		}
+		// distances between min and max, which can not overflow unlike the width and height
+		dx := uint(max.X) - uint(min.X)
+		dy := uint(max.Y) - uint(min.Y)
+		inRow := func(y int, row *set.IntSet[int]) bool {
+			if dx < uint(row.Size()) {
+				for i := range dx + 1 {
+					x := min.X + int(i)
+					if row.Contains(x) && !yield(Point{x, y}) {
+						return false
+					}
+				}
+				return true
+			}
+			for x := range row.All() {
+				if x >= min.X && x <= max.X && !yield(Point{x, y}) {
+					return false
+				}
+			}
+			return true
+		}
+		if dy < uint(len(s.rows)) {
+			for i := range dy + 1 {
+				y := min.Y + int(i)
+				if row, ok := s.rows[y]; ok && !inRow(y, row) {
+					return
+				}
+			}
+			return
+		}
+		for y, row := range s.rows {
+			if y >= min.Y && y <= max.Y && !inRow(y, row) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors returns an iterator over the points of set s,
+// which are neighbors of point p, including diagonal neighbors.
+// Point p itself is not included.
+func (s *PointSet) Neighbors(p Point) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for _, d := range neighborOffsets {
+			q := Point{p.X + d.X, p.Y + d.Y}
+			if s.Contains(q) && !yield(q) {
+				return
+			}
+		}
+	}
+}
+
+// Size returns the number of points in set s. An empty set returns 0.
+func (s *PointSet) Size() int {
+	return s.n
+}
+
+// String returns a string representation of set s.
+// Sets are printed with curly brackets and sorted by row, e.g. {(2,0) (1,1)}.
+func (s *PointSet) String() string {
+	p := slices.SortedFunc(s.All(), func(a, b Point) int {
+		return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+	})
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, q := range p {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(q.String())
+	}
+	b.WriteByte('}')
+	return b.String()
+}
@@ -0,0 +1,150 @@
+package grid_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/ErikKalkoken/go-set/grid"
+)
+
+func TestPointSet(t *testing.T) {
+	t.Run("can add and query points", func(t *testing.T) {
+		var s grid.PointSet
+		s.Add(grid.Point{1, 2}, grid.Point{3, 2}, grid.Point{1, 2}, grid.Point{-1, -5})
+		if got := s.Size(); got != 3 {
+			t.Errorf("got %v, wanted 3", got)
+		}
+		for _, p := range []grid.Point{{1, 2}, {3, 2}, {-1, -5}} {
+			if !s.Contains(p) {
+				t.Errorf("expected %v to be contained", p)
+			}
+		}
+		for _, p := range []grid.Point{{2, 2}, {1, 3}, {0, 0}} {
+			if s.Contains(p) {
+				t.Errorf("expected %v to not be contained", p)
+			}
+		}
+	})
+	t.Run("can delete points", func(t *testing.T) {
+		s := grid.Of(grid.Point{1, 2}, grid.Point{3, 2}, grid.Point{0, 0})
+		got := s.Delete(grid.Point{1, 2}, grid.Point{0, 0}, grid.Point{0, 0}, grid.Point{5, 5}, grid.Point{5, 2})
+		if got != 2 {
+			t.Errorf("got %v, wanted 2", got)
+		}
+		if got := s.Size(); got != 1 {
+			t.Errorf("got %v, wanted 1", got)
+		}
+		if want := "{(3,2)}"; s.String() != want {
+			t.Errorf("got %q, wanted %q", s, want)
+		}
+	})
+	t.Run("can iterate over all points", func(t *testing.T) {
+		want := set.Of(grid.Point{1, 2}, grid.Point{3, 2}, grid.Point{0, 0})
+		s := grid.Of(grid.Point{1, 2}, grid.Point{3, 2}, grid.Point{0, 0})
+		got := set.Collect(s.All())
+		if !got.Equal(want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+	t.Run("can stop iterating", func(t *testing.T) {
+		s := grid.Of(grid.Point{1, 2}, grid.Point{3, 2}, grid.Point{0, 0})
+		var n int
+		for range s.All() {
+			n++
+			break
+		}
+		if n != 1 {
+			t.Errorf("got %v, wanted 1", n)
+		}
+	})
+	t.Run("can clear", func(t *testing.T) {
+		s := grid.Of(grid.Point{1, 2})
+		s.Clear()
+		if s.Size() != 0 || s.Contains(grid.Point{1, 2}) {
+			t.Errorf("set not cleared")
+		}
+		s.Add(grid.Point{1, 2})
+		if s.Size() != 1 {
+			t.Errorf("can not reuse set after clear")
+		}
+	})
+	t.Run("can print sorted by rows", func(t *testing.T) {
+		s := grid.Of(grid.Point{1, 1}, grid.Point{2, 0}, grid.Point{0, 1})
+		if want := "{(2,0) (0,1) (1,1)}"; s.String() != want {
+			t.Errorf("got %q, wanted %q", s, want)
+		}
+	})
+}
+
+func TestPointSet_InRect(t *testing.T) {
+	// points of a dense block of 10x10 and a few scattered points
+	var s grid.PointSet
+	for y := range 10 {
+		for x := range 10 {
+			s.Add(grid.Point{x, y})
+		}
+	}
+	s.Add(grid.Point{100, 5}, grid.Point{-100, -100}, grid.Point{5, 100})
+	cases := []struct {
+		name     string
+		min, max grid.Point
+		want     int
+	}{
+		{"small rectangle", grid.Point{2, 3}, grid.Point{4, 4}, 6},
+		{"single point", grid.Point{5, 5}, grid.Point{5, 5}, 1},
+		{"wide rectangle", grid.Point{0, 5}, grid.Point{1000, 5}, 11},
+		{"tall rectangle", grid.Point{5, 0}, grid.Point{5, 1000}, 11},
+		{"everything", grid.Point{math.MinInt, math.MinInt}, grid.Point{math.MaxInt, math.MaxInt}, 103},
+		{"outside", grid.Point{20, 20}, grid.Point{30, 30}, 0},
+		{"inverted", grid.Point{4, 4}, grid.Point{2, 2}, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := set.Collect(s.InRect(tc.min, tc.max))
+			if got.Size() != tc.want {
+				t.Errorf("got %v, wanted %v", got.Size(), tc.want)
+			}
+			for p := range got.All() {
+				if p.X < tc.min.X || p.X > tc.max.X || p.Y < tc.min.Y || p.Y > tc.max.Y {
+					t.Errorf("got %v, which is outside the rectangle", p)
+				}
+			}
+		})
+	}
+	t.Run("can stop iterating", func(t *testing.T) {
+		for _, r := range [][2]grid.Point{
+			{{2, 3}, {4, 4}},
+			{{0, 0}, {1000, 1000}},
+			{{math.MinInt, math.MinInt}, {math.MaxInt, math.MaxInt}},
+		} {
+			var n int
+			for range s.InRect(r[0], r[1]) {
+				n++
+				break
+			}
+			if n != 1 {
+				t.Errorf("%v: got %v, wanted 1", r, n)
+			}
+		}
+	})
+}
+
+func TestPointSet_Neighbors(t *testing.T) {
+	s := grid.Of(grid.Point{0, 0}, grid.Point{1, 0}, grid.Point{1, 1}, grid.Point{-1, -1}, grid.Point{2, 2}, grid.Point{5, 5})
+	got := set.Collect(s.Neighbors(grid.Point{0, 0}))
+	want := set.Of(grid.Point{1, 0}, grid.Point{1, 1}, grid.Point{-1, -1})
+	if !got.Equal(want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	t.Run("can stop iterating", func(t *testing.T) {
+		var n int
+		for range s.Neighbors(grid.Point{0, 0}) {
+			n++
+			break
+		}
+		if n != 1 {
+			t.Errorf("got %v, wanted 1", n)
+		}
+	})
+}
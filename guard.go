@@ -0,0 +1,20 @@
+//go:build !setdebug
+
+package set
+
+// beginWrite marks the start of a write to the map of a set.
+// Detecting concurrent writes is only enabled with the setdebug build tag.
+func beginWrite[E comparable](m map[E]struct{}, op string) uintptr {
+	return 0
+}
+
+// endWrite marks the end of a write started with beginWrite.
+func endWrite(p uintptr) {}
+
+// guardWrite calls write, which writes to the map of a set and can panic,
+// e.g. for unhashable keys of interface types.
+// Only with the setdebug build tag the write is guarded
+// and ended with a deferred endWrite, so that the default build does not pay for it.
+func guardWrite[E comparable](m map[E]struct{}, op string, write func()) {
+	write()
+}
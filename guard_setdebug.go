@@ -0,0 +1,55 @@
+//go:build setdebug
+
+package set
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// guardSlots is the number of sets, which can be checked for concurrent writes at the same time.
+const guardSlots = 1 << 12
+
+// guards contains the maps of the sets, which are currently written to.
+// The slot of a map is determined by its address.
+var guards [guardSlots]atomic.Uintptr
+
+// beginWrite marks the start of a write to the map of a set by operation op
+// and returns a key for ending the write.
+// It panics when another write to the same map is in progress.
+// Writes, which can panic, e.g. for unhashable keys of interface types,
+// must use guardWrite instead, so that the map is not left marked as being written to.
+//
+// Writes to different maps, which share the same slot, are not checked.
+// Therefore concurrent writes might go undetected, but are never falsely reported.
+func beginWrite[E comparable](m map[E]struct{}, op string) uintptr {
+	p := *(*uintptr)(unsafe.Pointer(&m))
+	if p == 0 {
+		return 0
+	}
+	g := &guards[mix64(uint64(p))%guardSlots]
+	if g.CompareAndSwap(0, p) {
+		return p
+	}
+	if g.Load() == p {
+		panic("set." + op + ": concurrent write to set detected")
+	}
+	return 0
+}
+
+// guardWrite calls write, which writes to the map of a set and can panic,
+// e.g. for unhashable keys of interface types,
+// between beginWrite and a deferred endWrite,
+// so that the map is not left marked as being written to when write panics.
+func guardWrite[E comparable](m map[E]struct{}, op string, write func()) {
+	p := beginWrite(m, op)
+	defer endWrite(p)
+	write()
+}
+
+// endWrite marks the end of a write started with beginWrite.
+func endWrite(p uintptr) {
+	if p != 0 {
+		guards[mix64(uint64(p))%guardSlots].Store(0)
+	}
+}
@@ -0,0 +1,100 @@
+//go:build setdebug
+
+package set
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGuard(t *testing.T) {
+	t.Run("should allow consecutive writes", func(t *testing.T) {
+		m := make(map[int]struct{})
+		endWrite(beginWrite(m, "Add"))
+		endWrite(beginWrite(m, "Add"))
+	})
+	t.Run("should allow concurrent writes to different maps", func(t *testing.T) {
+		m1 := make(map[int]struct{})
+		m2 := make(map[int]struct{})
+		p := beginWrite(m1, "Add")
+		endWrite(beginWrite(m2, "Add"))
+		endWrite(p)
+	})
+	t.Run("should ignore nil maps", func(t *testing.T) {
+		var m map[int]struct{}
+		p := beginWrite(m, "Add")
+		endWrite(beginWrite(m, "Add"))
+		endWrite(p)
+	})
+	t.Run("should panic on concurrent writes to the same map", func(t *testing.T) {
+		m := make(map[int]struct{})
+		p := beginWrite(m, "Add")
+		defer endWrite(p)
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic when it was expected to")
+			}
+		}()
+		beginWrite(m, "Delete")
+	})
+	t.Run("should detect concurrent writes through set", func(t *testing.T) {
+		s := Of(1, 2, 3)
+		p := beginWrite(s.m, "Add")
+		defer endWrite(p)
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic when it was expected to")
+			}
+		}()
+		s.Clear()
+	})
+	t.Run("should detect concurrent writes through other functions", func(t *testing.T) {
+		cases := []struct {
+			name string
+			fn   func(s Set[float64])
+		}{
+			{"delete NaN", func(s Set[float64]) { DeleteNaN(s) }},
+			{"pool put", func(s Set[float64]) { new(Pool[float64]).Put(s) }},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				s := Of(1, math.NaN())
+				p := beginWrite(s.m, "Add")
+				defer endWrite(p)
+				defer func() {
+					if r := recover(); r == nil {
+						t.Errorf("The code did not panic when it was expected to")
+					}
+				}()
+				tc.fn(s)
+			})
+		}
+	})
+	t.Run("should release guard when write panics", func(t *testing.T) {
+		cases := []struct {
+			name string
+			fn   func(s Set[any])
+		}{
+			{"add", func(s Set[any]) { s.Add([]int{1}) }},
+			{"delete", func(s Set[any]) { s.Delete([]int{1}) }},
+			{"add map values", func(s Set[any]) { AddMapValues(&s, map[int]any{1: []int{1}}) }},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				s := Of[any](1)
+				func() {
+					defer func() {
+						if r := recover(); r == nil {
+							t.Errorf("The code did not panic when it was expected to")
+						}
+					}()
+					tc.fn(s)
+				}()
+				s.Add(2)
+				if !s.Contains(2) {
+					t.Errorf("got %v, wanted 2 to be added", s)
+				}
+			})
+		}
+	})
+}
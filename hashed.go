@@ -0,0 +1,102 @@
+package set
+
+import "iter"
+
+// A HashedSet is a set, which maintains an order-independent hash of its elements.
+//
+// The hash is updated incrementally when elements are added or deleted.
+// This allows [HashedSet.Equal] to detect most unequal sets of the same size in constant time,
+// which is useful for large sets that are compared much more often than they are changed.
+// Sets which are equal in size and hash are still compared element by element.
+//
+// Sets can only be compared when they use the same hash function.
+// For comparing hashes between processes the hash function needs to be deterministic,
+// e.g. one based on hash/fnv.
+// HashedSet is not safe for concurrent use.
+type HashedSet[E comparable] struct {
+	s    Set[E]
+	hash func(E) uint64
+	sum  uint64
+}
+
+// NewHashedSet returns a new hashed set with the elements v,
+// which uses the function hash to hash elements.
+func NewHashedSet[E comparable](hash func(E) uint64, v ...E) *HashedSet[E] {
+	s := &HashedSet[E]{hash: hash}
+	s.Add(v...)
+	return s
+}
+
+// Add adds elements v to set s.
+func (s *HashedSet[E]) Add(v ...E) {
+	for _, x := range v {
+		if s.s.Contains(x) {
+			continue
+		}
+		s.s.Add(x)
+		s.sum += mix64(s.hash(x))
+	}
+}
+
+// All returns on iterator over all elements of set s.
+//
+// Note that the order of the elements is undefined.
+func (s *HashedSet[E]) All() iter.Seq[E] {
+	return s.s.All()
+}
+
+// Clear removes all elements from set s.
+func (s *HashedSet[E]) Clear() {
+	s.s.Clear()
+	s.sum = 0
+}
+
+// Contains reports whether element v is in set s.
+func (s *HashedSet[E]) Contains(v E) bool {
+	return s.s.Contains(v)
+}
+
+// Delete removes elements v from set s.
+// It returns the number of deleted elements.
+// Elements that are not found in the set are ignored.
+func (s *HashedSet[E]) Delete(v ...E) int {
+	var c int
+	for _, x := range v {
+		if s.s.Delete(x) == 1 {
+			s.sum -= mix64(s.hash(x))
+			c++
+		}
+	}
+	return c
+}
+
+// Equal reports whether sets s and u are equal.
+// Sets with different sizes or hashes are reported unequal without comparing their elements.
+func (s *HashedSet[E]) Equal(u *HashedSet[E]) bool {
+	if s.s.Size() != u.s.Size() || s.sum != u.sum {
+		return false
+	}
+	return s.s.Equal(u.s)
+}
+
+// Hash returns the order-independent hash of the elements of set s.
+// Equal sets always have the same hash, which is 0 for empty sets.
+func (s *HashedSet[E]) Hash() uint64 {
+	return s.sum
+}
+
+// Set returns a new set with the elements of set s.
+func (s *HashedSet[E]) Set() Set[E] {
+	return s.s.Clone()
+}
+
+// Size returns the number of elements in set s. An empty set returns 0.
+func (s *HashedSet[E]) Size() int {
+	return s.s.Size()
+}
+
+// String returns a string representation of set s.
+// Sets are printed with curly brackets and sorted, e.g. {1 2}.
+func (s *HashedSet[E]) String() string {
+	return s.s.String()
+}
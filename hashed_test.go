@@ -0,0 +1,77 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestHashedSet(t *testing.T) {
+	t.Run("should have same hash independent of order", func(t *testing.T) {
+		s1 := set.NewHashedSet(hashInt, 1, 2, 3)
+		s2 := set.NewHashedSet(hashInt, 3, 2, 1, 2)
+		if s1.Hash() != s2.Hash() {
+			t.Errorf("got %v and %v, wanted same hash", s1.Hash(), s2.Hash())
+		}
+		if s1.Hash() == 0 {
+			t.Errorf("wanted non-zero hash")
+		}
+	})
+	t.Run("should update hash incrementally", func(t *testing.T) {
+		s := set.NewHashedSet(hashInt, 1, 2)
+		want := set.NewHashedSet(hashInt, 1).Hash()
+		s.Add(3)
+		if got := s.Delete(2, 3, 4); got != 2 {
+			t.Errorf("got %v, wanted 2", got)
+		}
+		if got := s.Hash(); got != want {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+		s.Clear()
+		if got := s.Hash(); got != 0 {
+			t.Errorf("got %v, wanted 0", got)
+		}
+		if s.Size() != 0 {
+			t.Errorf("set not cleared")
+		}
+	})
+	t.Run("can compare sets", func(t *testing.T) {
+		// collide maps all elements to the same hash
+		collide := func(int) uint64 {
+			return 1
+		}
+		cases := []struct {
+			name string
+			s, u *set.HashedSet[int]
+			want bool
+		}{
+			{"equal sets", set.NewHashedSet(hashInt, 1, 2), set.NewHashedSet(hashInt, 2, 1), true},
+			{"different elements", set.NewHashedSet(hashInt, 1, 2), set.NewHashedSet(hashInt, 1, 3), false},
+			{"different sizes", set.NewHashedSet(hashInt, 1, 2), set.NewHashedSet(hashInt, 1), false},
+			{"same hashes", set.NewHashedSet(collide, 1, 2), set.NewHashedSet(collide, 1, 3), false},
+			{"empty sets", set.NewHashedSet(hashInt), set.NewHashedSet(hashInt), true},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				if got := tc.s.Equal(tc.u); got != tc.want {
+					t.Errorf("got %v, wanted %v", got, tc.want)
+				}
+			})
+		}
+	})
+	t.Run("can access elements", func(t *testing.T) {
+		s := set.NewHashedSet(hashInt, 1, 2)
+		if !s.Contains(1) || s.Contains(3) {
+			t.Errorf("contains reported wrong result")
+		}
+		if got, want := set.Collect(s.All()), set.Of(1, 2); !got.Equal(want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+		if got, want := s.Set(), set.Of(1, 2); !got.Equal(want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+		if got, want := s.String(), "{1 2}"; got != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,224 @@
+package set
+
+import (
+	"encoding/binary"
+	"slices"
+)
+
+// ibltHashes is the number of cells each key is stored in.
+const ibltHashes = 3
+
+var ibltSeeds = [ibltHashes + 1]uint64{
+	0x9e3779b97f4a7c15,
+	0xbf58476d1ce4e5b9,
+	0x94d049bb133111eb,
+	0x2545f4914f6cdd1d,
+}
+
+// An IBLT is an invertible Bloom lookup table of 64-bit keys.
+//
+// IBLTs can be used by two parties to find the symmetric difference of two large sets,
+// which are mostly identical, with communication proportional to the size of the difference:
+// Each party inserts the hashes of its elements into an IBLT of the same size.
+// One party sends its IBLT to the other party, which subtracts it from its own IBLT.
+// Decoding the result recovers the hashes of the elements, which only exist on one side.
+// These hashes can then be resolved to elements with [ElementsWithHash].
+//
+// The size of an IBLT needs to be chosen based on the expected size of the symmetric difference,
+// not the size of the sets. A size of at least twice the expected difference is recommended.
+// Decoding will fail when the difference is too large for the size of the IBLT.
+//
+// Both parties need to use the same deterministic hash function for their elements,
+// e.g. one based on hash/fnv. Distinct elements with the same hash can not be told apart.
+type IBLT struct {
+	cells []ibltCell
+}
+
+type ibltCell struct {
+	count   int64
+	keySum  uint64
+	hashSum uint64
+}
+
+// NewIBLT returns a new empty IBLT with at least size cells.
+// It panics if size is not positive.
+func NewIBLT(size int) *IBLT {
+	if size < 1 {
+		panic("set.NewIBLT: size must be positive")
+	}
+	m := (size + ibltHashes - 1) / ibltHashes
+	return &IBLT{cells: make([]ibltCell, m*ibltHashes)}
+}
+
+// ToIBLT returns a new IBLT with at least size cells,
+// which contains the hashes of all elements of set s.
+func ToIBLT[E comparable](s Set[E], size int, hash func(E) uint64) *IBLT {
+	t := NewIBLT(size)
+	for v := range s.All() {
+		t.Insert(hash(v))
+	}
+	return t
+}
+
+// ElementsWithHash returns a new set with the elements of set s,
+// which have one of the given hashes.
+func ElementsWithHash[E comparable](s Set[E], hashes []uint64, hash func(E) uint64) Set[E] {
+	var r Set[E]
+	if len(hashes) == 0 {
+		return r
+	}
+	h := Of(hashes...)
+	for v := range s.All() {
+		if h.Contains(hash(v)) {
+			r.Add(v)
+		}
+	}
+	return r
+}
+
+// Decode tries to recover all keys from IBLT t and reports whether it was successful.
+//
+// When t is the result of subtracting IBLT u from t, local contains the keys which only exist in t
+// and remote contains the keys which only exist in u.
+// For an IBLT without subtraction all keys are returned in local.
+// t itself is not changed.
+func (t *IBLT) Decode() (local, remote []uint64, ok bool) {
+	cells := slices.Clone(t.cells)
+	m := len(cells) / ibltHashes
+	var pure []int
+	for i, c := range cells {
+		if c.isPure() {
+			pure = append(pure, i)
+		}
+	}
+	for len(pure) > 0 {
+		i := pure[len(pure)-1]
+		pure = pure[:len(pure)-1]
+		c := cells[i]
+		if !c.isPure() {
+			continue
+		}
+		key := c.keySum
+		if c.count == 1 {
+			local = append(local, key)
+		} else {
+			remote = append(remote, key)
+		}
+		for j := range ibltHashes {
+			k := ibltIndex(key, j, m)
+			cells[k].update(key, -c.count)
+			if cells[k].isPure() {
+				pure = append(pure, k)
+			}
+		}
+	}
+	for _, c := range cells {
+		if c != (ibltCell{}) {
+			return nil, nil, false
+		}
+	}
+	return local, remote, true
+}
+
+// Delete removes key from IBLT t.
+func (t *IBLT) Delete(key uint64) {
+	t.update(key, -1)
+}
+
+// Insert adds key to IBLT t.
+func (t *IBLT) Insert(key uint64) {
+	t.update(key, 1)
+}
+
+// Size returns the number of cells of IBLT t.
+func (t *IBLT) Size() int {
+	return len(t.cells)
+}
+
+// Subtract subtracts IBLT u from IBLT t.
+// It panics if both IBLTs do not have the same size.
+func (t *IBLT) Subtract(u *IBLT) {
+	if len(t.cells) != len(u.cells) {
+		panic("set.IBLT.Subtract: different sizes")
+	}
+	for i, c := range u.cells {
+		t.cells[i].count -= c.count
+		t.cells[i].keySum ^= c.keySum
+		t.cells[i].hashSum ^= c.hashSum
+	}
+}
+
+// MarshalBinary returns the binary encoding of IBLT t.
+func (t *IBLT) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, binary.MaxVarintLen64+len(t.cells)*18)
+	b = binary.AppendUvarint(b, uint64(len(t.cells)))
+	for _, c := range t.cells {
+		b = binary.AppendVarint(b, c.count)
+		b = binary.LittleEndian.AppendUint64(b, c.keySum)
+		b = binary.LittleEndian.AppendUint64(b, c.hashSum)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary parses the binary encoded data b and replaces IBLT t.
+func (t *IBLT) UnmarshalBinary(b []byte) error {
+	n, k := binary.Uvarint(b)
+	if k <= 0 || n == 0 || n%ibltHashes != 0 || n > uint64(len(b)) {
+		return errInvalidIBLT
+	}
+	b = b[k:]
+	cells := make([]ibltCell, n)
+	for i := range cells {
+		count, k := binary.Varint(b)
+		if k <= 0 || len(b) < k+16 {
+			return errInvalidIBLT
+		}
+		cells[i] = ibltCell{
+			count:   count,
+			keySum:  binary.LittleEndian.Uint64(b[k:]),
+			hashSum: binary.LittleEndian.Uint64(b[k+8:]),
+		}
+		b = b[k+16:]
+	}
+	if len(b) != 0 {
+		return errInvalidIBLT
+	}
+	t.cells = cells
+	return nil
+}
+
+func (t *IBLT) update(key uint64, count int64) {
+	m := len(t.cells) / ibltHashes
+	for j := range ibltHashes {
+		t.cells[ibltIndex(key, j, m)].update(key, count)
+	}
+}
+
+func (c *ibltCell) update(key uint64, count int64) {
+	c.count += count
+	c.keySum ^= key
+	c.hashSum ^= ibltCheck(key)
+}
+
+func (c ibltCell) isPure() bool {
+	return (c.count == 1 || c.count == -1) && c.hashSum == ibltCheck(c.keySum)
+}
+
+// ibltIndex returns the index of the cell for key in sub table j of size m.
+func ibltIndex(key uint64, j, m int) int {
+	return j*m + int(mix64(key^ibltSeeds[j])%uint64(m))
+}
+
+func ibltCheck(key uint64) uint64 {
+	return mix64(key ^ ibltSeeds[ibltHashes])
+}
+
+// mix64 returns a well distributed hash of x using the splitmix64 finalizer.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
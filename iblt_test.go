@@ -0,0 +1,170 @@
+package set_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func hashInt(v int) uint64 {
+	return uint64(v) * 0x9e3779b97f4a7c15
+}
+
+func TestIBLT(t *testing.T) {
+	t.Run("can find symmetric difference", func(t *testing.T) {
+		var a, b set.Set[int]
+		for i := range 10_000 {
+			a.Add(i)
+			b.Add(i)
+		}
+		a.Add(-1, -2, -3)
+		b.Delete(17, 42)
+		b.Add(20_000)
+		ta := set.ToIBLT(a, 20, hashInt)
+		tb := set.ToIBLT(b, 20, hashInt)
+		ta.Subtract(tb)
+		local, remote, ok := ta.Decode()
+		if !ok {
+			t.Fatal("decoding failed")
+		}
+		gotLocal := set.ElementsWithHash(a, local, hashInt)
+		wantLocal := set.Of(-1, -2, -3, 17, 42)
+		if !gotLocal.Equal(wantLocal) {
+			t.Errorf("local: got %q, wanted %q", gotLocal, wantLocal)
+		}
+		gotRemote := set.ElementsWithHash(b, remote, hashInt)
+		wantRemote := set.Of(20_000)
+		if !gotRemote.Equal(wantRemote) {
+			t.Errorf("remote: got %q, wanted %q", gotRemote, wantRemote)
+		}
+	})
+	t.Run("can decode all keys", func(t *testing.T) {
+		tb := set.NewIBLT(10)
+		tb.Insert(1)
+		tb.Insert(2)
+		tb.Insert(3)
+		tb.Delete(3)
+		local, remote, ok := tb.Decode()
+		if !ok {
+			t.Fatal("decoding failed")
+		}
+		slices.Sort(local)
+		if want := []uint64{1, 2}; !slices.Equal(local, want) {
+			t.Errorf("got %v, wanted %v", local, want)
+		}
+		if len(remote) != 0 {
+			t.Errorf("got %v, wanted empty", remote)
+		}
+	})
+	t.Run("can decode empty", func(t *testing.T) {
+		local, remote, ok := set.NewIBLT(1).Decode()
+		if !ok || len(local) != 0 || len(remote) != 0 {
+			t.Errorf("got %v %v %v, wanted empty result", local, remote, ok)
+		}
+	})
+	t.Run("should report when decoding fails", func(t *testing.T) {
+		tb := set.NewIBLT(3)
+		for i := range 100 {
+			tb.Insert(hashInt(i))
+		}
+		_, _, ok := tb.Decode()
+		if ok {
+			t.Errorf("decoding did not fail")
+		}
+	})
+	t.Run("should round size up to multiple of hashes", func(t *testing.T) {
+		got := set.NewIBLT(10).Size()
+		if got != 12 {
+			t.Errorf("got %v, wanted 12", got)
+		}
+	})
+	t.Run("can marshal and unmarshal", func(t *testing.T) {
+		t1 := set.ToIBLT(set.Of(1, 2, 3), 9, hashInt)
+		t1.Delete(hashInt(4))
+		b, err := t1.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t2 := set.NewIBLT(1)
+		if err := t2.UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+		local, remote, ok := t2.Decode()
+		if !ok {
+			t.Fatal("decoding failed")
+		}
+		slices.Sort(local)
+		want := []uint64{hashInt(1), hashInt(2), hashInt(3)}
+		slices.Sort(want)
+		if !slices.Equal(local, want) {
+			t.Errorf("got %v, wanted %v", local, want)
+		}
+		if want := []uint64{hashInt(4)}; !slices.Equal(remote, want) {
+			t.Errorf("got %v, wanted %v", remote, want)
+		}
+	})
+	t.Run("should return error when unmarshalling invalid data", func(t *testing.T) {
+		valid, err := set.NewIBLT(3).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		cases := []struct {
+			name string
+			b    []byte
+		}{
+			{"empty", []byte{}},
+			{"zero size", []byte{0}},
+			{"size not multiple of hashes", []byte{4, 0}},
+			{"truncated", valid[:len(valid)-1]},
+			{"trailing data", append(valid, 0)},
+			{"invalid count", append([]byte{3}, slices.Repeat([]byte{0xff}, 20)...)},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				var tb set.IBLT
+				if err := tb.UnmarshalBinary(tc.b); err == nil {
+					t.Errorf("got %q, wanted error", err)
+				}
+			})
+		}
+	})
+	t.Run("should panic when subtracting IBLT with different size", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic when it was expected to")
+			}
+		}()
+		set.NewIBLT(3).Subtract(set.NewIBLT(6))
+	})
+	t.Run("should panic when size is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic when it was expected to")
+			}
+		}()
+		set.NewIBLT(0)
+	})
+}
+
+func TestElementsWithHash(t *testing.T) {
+	cases := []struct {
+		name   string
+		s      set.Set[int]
+		hashes []uint64
+		want   set.Set[int]
+	}{
+		{"some match", set.Of(1, 2, 3), []uint64{hashInt(1), hashInt(3), hashInt(4)}, set.Of(1, 3)},
+		{"none match", set.Of(1, 2, 3), []uint64{hashInt(4)}, set.Of[int]()},
+		{"no hashes", set.Of(1, 2, 3), []uint64{}, set.Of[int]()},
+		{"empty set", set.Of[int](), []uint64{hashInt(1)}, set.Of[int]()},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := set.ElementsWithHash(tc.s, tc.hashes, hashInt)
+			if !got.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package set
+
+import "sync"
+
+// An Interner canonicalizes equal sets to a single shared [FrozenSet].
+//
+// This is similar to string interning, but for whole sets:
+// many duplicate sets, e.g. small sets of tags in an index, share the memory of one frozen set,
+// and interned sets from the same interner are equal exactly when their pointers are equal.
+// Sets are looked up by an order-independent hash of their elements
+// and compared element by element only when the hashes match.
+//
+// Interned sets are kept for the lifetime of the interner.
+// Interner is safe for concurrent use.
+type Interner[E comparable] struct {
+	hash func(E) uint64
+	mu   sync.Mutex
+	sets map[uint64][]*FrozenSet[E]
+	n    int
+}
+
+// NewInterner returns a new empty interner, which uses the function hash to hash elements.
+func NewInterner[E comparable](hash func(E) uint64) *Interner[E] {
+	return &Interner[E]{hash: hash, sets: make(map[uint64][]*FrozenSet[E])}
+}
+
+// Intern returns the canonical frozen set with the elements of set s.
+// If the interner has no equal set yet, set s is frozen with [Freeze] and added to the interner.
+// It returns an error if freezing the set failed.
+func (in *Interner[E]) Intern(s Set[E]) (*FrozenSet[E], error) {
+	var h uint64
+	for v := range s.All() {
+		h += mix64(in.hash(v))
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	for _, f := range in.sets[h] {
+		if f.equal(s) {
+			return f, nil
+		}
+	}
+	f, err := Freeze(s, in.hash)
+	if err != nil {
+		return nil, err
+	}
+	in.sets[h] = append(in.sets[h], f)
+	in.n++
+	return f, nil
+}
+
+// Size returns the number of distinct sets in interner in.
+func (in *Interner[E]) Size() int {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.n
+}
+
+// equal reports whether frozen set s has the same elements as set u.
+func (s *FrozenSet[E]) equal(u Set[E]) bool {
+	for v := range u.All() {
+		if !s.Contains(v) {
+			return false
+		}
+	}
+	return s.Size() == u.Size()
+}
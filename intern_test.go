@@ -0,0 +1,97 @@
+package set_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestInterner(t *testing.T) {
+	t.Run("should return same frozen set for equal sets", func(t *testing.T) {
+		in := set.NewInterner(hashString)
+		a, err := in.Intern(set.Of("a", "b"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := in.Intern(set.Of("b", "a"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a != b {
+			t.Errorf("got different sets %q and %q, wanted same set", a, b)
+		}
+		if got := a.String(); got != "{a b}" {
+			t.Errorf("got %s, wanted {a b}", got)
+		}
+		if got := in.Size(); got != 1 {
+			t.Errorf("got %d, wanted 1", got)
+		}
+	})
+	t.Run("should return different frozen sets for different sets", func(t *testing.T) {
+		in := set.NewInterner(hashString)
+		cases := []set.Set[string]{set.Of[string](), set.Of("a"), set.Of("a", "b"), set.Of("c")}
+		seen := make(map[*set.FrozenSet[string]]bool)
+		for _, s := range cases {
+			f, err := in.Intern(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if seen[f] {
+				t.Errorf("got same frozen set for %q", s)
+			}
+			seen[f] = true
+		}
+		if got := in.Size(); got != len(cases) {
+			t.Errorf("got %d, wanted %d", got, len(cases))
+		}
+	})
+	t.Run("should compare sets with same hash", func(t *testing.T) {
+		// with a constant hash function all sets of the same size have the same content hash
+		in := set.NewInterner(func(int) uint64 { return 0 })
+		a, err := in.Intern(set.Of(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := in.Intern(set.Of(2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := in.Intern(set.Of(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a == b || a != c {
+			t.Errorf("got %q, %q and %q, wanted first and last to be the same", a, b, c)
+		}
+	})
+	t.Run("should return error when freezing fails", func(t *testing.T) {
+		in := set.NewInterner(func(int) uint64 { return 0 })
+		_, err := in.Intern(set.Of(1, 2))
+		if !errors.Is(err, set.ErrHashCollision) {
+			t.Errorf("got %v, wanted %v", err, set.ErrHashCollision)
+		}
+		if got := in.Size(); got != 0 {
+			t.Errorf("got %d, wanted 0", got)
+		}
+	})
+	t.Run("should be safe for concurrent use", func(t *testing.T) {
+		in := set.NewInterner(hashInt)
+		var wg sync.WaitGroup
+		got := make([]*set.FrozenSet[int], 10)
+		for i := range got {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				got[i], _ = in.Intern(set.Of(1, 2, 3))
+			}()
+		}
+		wg.Wait()
+		for _, f := range got[1:] {
+			if f != got[0] {
+				t.Fatalf("got different sets, wanted same set")
+			}
+		}
+	})
+}
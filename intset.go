@@ -0,0 +1,228 @@
+package set
+
+import (
+	"iter"
+	"math/bits"
+	"unsafe"
+)
+
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+const (
+	// intSetSparseBytes is the estimated memory usage of an element in the sparse representation.
+	intSetSparseBytes = 16
+	// intSetMinDense is the minimum number of elements for switching to the dense representation.
+	intSetMinDense = 64
+)
+
+// An IntSet is a set of integers, which automatically switches between
+// a sparse representation as hash set and a dense representation as bitset.
+//
+// The representation is chosen based on the density of the values:
+// An IntSet switches to a bitset when it would use less than half the memory of the hash set.
+// It switches back when adding a value would grow the bitset to more than twice the memory of the hash set
+// or when deleting values leaves the bitset with more than four times the memory of the hash set.
+// The larger factor for deleting allows for the spare capacity of a grown bitset,
+// so that deleting a value right after growing it does not switch back immediately.
+// This makes IntSet efficient both for small sets of scattered values
+// and for large sets of contiguous values.
+//
+// The zero value of an IntSet is an empty set ready to use.
+// IntSet is not safe for concurrent use.
+type IntSet[E integer] struct {
+	sparse Set[E]
+	words  []uint64 // dense representation, nil when sparse
+	lo     E        // value of the first bit in the dense representation
+	n      int
+	// lower and upper bound of the values in the sparse representation.
+	// Bounds are not updated when deleting elements and can therefore be too wide.
+	min, max E
+}
+
+// Add adds elements v to set s.
+func (s *IntSet[E]) Add(v ...E) {
+	for _, x := range v {
+		if s.words != nil {
+			s.addDense(x)
+			continue
+		}
+		if s.n == 0 {
+			s.min, s.max = x, x
+		} else {
+			s.min, s.max = min(s.min, x), max(s.max, x)
+		}
+		s.sparse.Add(x)
+		s.n = s.sparse.Size()
+		if s.n >= intSetMinDense && 2*intSetWords(s.min, s.max)*8 <= uint64(s.n)*intSetSparseBytes {
+			s.toDense()
+		}
+	}
+}
+
+// All returns an iterator over all elements of set s.
+//
+// Note that the order of the elements is undefined.
+func (s *IntSet[E]) All() iter.Seq[E] {
+	if s.words == nil {
+		return s.sparse.All()
+	}
+	return func(yield func(E) bool) {
+		for i, w := range s.words {
+			for w != 0 {
+				b := bits.TrailingZeros64(w)
+				if !yield(s.value(i*64 + b)) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// Clear removes all elements from set s.
+func (s *IntSet[E]) Clear() {
+	*s = IntSet[E]{}
+}
+
+// Contains reports whether element v is in set s.
+func (s *IntSet[E]) Contains(v E) bool {
+	if s.words == nil {
+		return s.sparse.Contains(v)
+	}
+	i, ok := s.index(v)
+	return ok && s.words[i/64]&(1<<(i%64)) != 0
+}
+
+// Delete removes elements v from set s.
+// It returns the number of deleted elements.
+// Elements that are not found in the set are ignored.
+func (s *IntSet[E]) Delete(v ...E) int {
+	ln := s.n
+	for _, x := range v {
+		if s.words == nil {
+			s.sparse.Delete(x)
+			s.n = s.sparse.Size()
+			continue
+		}
+		i, ok := s.index(x)
+		if !ok || s.words[i/64]&(1<<(i%64)) == 0 {
+			continue
+		}
+		s.words[i/64] &^= 1 << (i % 64)
+		s.n--
+		// a grown bitset can have up to twice the needed size, see addDense
+		if uint64(len(s.words))*8 > 4*uint64(s.n)*intSetSparseBytes {
+			s.toSparse()
+		}
+	}
+	return ln - s.n
+}
+
+// IsDense reports whether set s currently uses the dense representation.
+func (s *IntSet[E]) IsDense() bool {
+	return s.words != nil
+}
+
+// Size returns the number of elements in set s. An empty set returns 0.
+func (s *IntSet[E]) Size() int {
+	return s.n
+}
+
+// String returns a string representation of set s.
+// Sets are printed with curly brackets and sorted, e.g. {1 2}.
+func (s *IntSet[E]) String() string {
+	return Collect(s.All()).String()
+}
+
+func (s *IntSet[E]) addDense(v E) {
+	if i, ok := s.index(v); ok {
+		if s.words[i/64]&(1<<(i%64)) == 0 {
+			s.words[i/64] |= 1 << (i % 64)
+			s.n++
+		}
+		return
+	}
+	var need uint64 // number of words needed for the extended range
+	if v < s.lo {
+		need = intSetWords(v, s.lo) + uint64(len(s.words))
+	} else {
+		need = intSetWords(s.lo, v)
+	}
+	if need*8 > 2*uint64(s.n+1)*intSetSparseBytes {
+		s.toSparse()
+		s.Add(v)
+		return
+	}
+	// grow by at least doubling the size to amortize the cost of rebuilding
+	size := max(need, 2*uint64(len(s.words)))
+	lo := s.lo
+	if v < s.lo {
+		lo = intSetLower(s.lo, (size-uint64(len(s.words)))*64)
+	}
+	s.rebuild(lo, int(size))
+	s.addDense(v)
+}
+
+// index returns the index of the bit for v and reports whether v is within the bitset.
+func (s *IntSet[E]) index(v E) (uint64, bool) {
+	if v < s.lo {
+		return 0, false
+	}
+	i := uint64(v) - uint64(s.lo)
+	return i, i < uint64(len(s.words))*64
+}
+
+// rebuild rebuilds the bitset with the new lower bound lo and size words.
+func (s *IntSet[E]) rebuild(lo E, size int) {
+	old := s.All()
+	s2 := IntSet[E]{lo: lo, words: make([]uint64, size)}
+	for v := range old {
+		i, _ := s2.index(v)
+		s2.words[i/64] |= 1 << (i % 64)
+		s2.n++
+	}
+	*s = s2
+}
+
+func (s *IntSet[E]) toDense() {
+	lo, hi := Min(s.sparse), Max(s.sparse)
+	s.rebuild(lo, int(intSetWords(lo, hi)))
+}
+
+func (s *IntSet[E]) toSparse() {
+	var s2 IntSet[E]
+	for v := range s.All() {
+		if s2.n == 0 {
+			s2.min, s2.max = v, v
+		} else {
+			s2.min, s2.max = min(s2.min, v), max(s2.max, v)
+		}
+		s2.sparse.Add(v)
+		s2.n++
+	}
+	*s = s2
+}
+
+func (s *IntSet[E]) value(i int) E {
+	return E(uint64(s.lo) + uint64(i))
+}
+
+// intSetWords returns the number of words needed for a bitset covering the values from lo to hi.
+func intSetWords[E integer](lo, hi E) uint64 {
+	return (uint64(hi)-uint64(lo))/64 + 1
+}
+
+// intSetLower returns the value d below v or the minimum value of E if that would underflow.
+func intSetLower[E integer](v E, d uint64) E {
+	x := E(uint64(v) - d)
+	if x < v && uint64(v)-uint64(x) == d {
+		return x
+	}
+	var m E
+	if m-1 < 0 { // signed
+		m = E(1) << (unsafe.Sizeof(m)*8 - 1)
+	}
+	return m
+}
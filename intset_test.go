@@ -0,0 +1,187 @@
+package set_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestIntSet(t *testing.T) {
+	t.Run("can add, check and delete elements", func(t *testing.T) {
+		var s set.IntSet[int]
+		s.Add(3, 1, 2, 3)
+		if s.Size() != 3 {
+			t.Errorf("got %v, wanted 3", s.Size())
+		}
+		if !s.Contains(2) || s.Contains(4) {
+			t.Errorf("contains reported wrong result")
+		}
+		if got := s.Delete(2, 4); got != 1 {
+			t.Errorf("got %v, wanted 1", got)
+		}
+		want := set.Of(1, 3)
+		if got := set.Collect(s.All()); !got.Equal(want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+		if got := s.String(); got != "{1 3}" {
+			t.Errorf("got %q, wanted {1 3}", got)
+		}
+	})
+	t.Run("should switch to dense for contiguous values", func(t *testing.T) {
+		var s set.IntSet[int]
+		for i := range 1000 {
+			s.Add(i + 5000)
+		}
+		if !s.IsDense() {
+			t.Errorf("expected dense representation")
+		}
+		if s.Size() != 1000 || !s.Contains(5000) || !s.Contains(5999) || s.Contains(4999) || s.Contains(6000) {
+			t.Errorf("dense set has wrong content")
+		}
+	})
+	t.Run("should stay sparse for scattered values", func(t *testing.T) {
+		var s set.IntSet[int]
+		for i := range 1000 {
+			s.Add(i * 1000)
+		}
+		if s.IsDense() {
+			t.Errorf("expected sparse representation")
+		}
+	})
+	t.Run("should switch to sparse when adding far away values", func(t *testing.T) {
+		var s set.IntSet[int]
+		for i := range 100 {
+			s.Add(i)
+		}
+		s.Add(1 << 40)
+		if s.IsDense() {
+			t.Errorf("expected sparse representation")
+		}
+		if s.Size() != 101 || !s.Contains(1<<40) || !s.Contains(99) {
+			t.Errorf("set has wrong content")
+		}
+	})
+	t.Run("should switch to sparse when deleting most values", func(t *testing.T) {
+		var s set.IntSet[int]
+		for i := range 100_000 {
+			s.Add(i)
+		}
+		if got := s.Delete(-1, 100_000); got != 0 {
+			t.Errorf("got %v, wanted 0", got)
+		}
+		for i := range 99_990 {
+			s.Delete(i)
+		}
+		if s.IsDense() {
+			t.Errorf("expected sparse representation")
+		}
+		want := set.Of(99_990, 99_991, 99_992, 99_993, 99_994, 99_995, 99_996, 99_997, 99_998, 99_999)
+		if got := set.Collect(s.All()); !got.Equal(want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+	t.Run("can grow dense set in both directions", func(t *testing.T) {
+		var s set.IntSet[int]
+		for i := range 10_000 {
+			s.Add(-i)
+			s.Add(i)
+		}
+		if !s.IsDense() {
+			t.Errorf("expected dense representation")
+		}
+		if s.Size() != 19_999 || !s.Contains(-9999) || !s.Contains(9999) || s.Contains(10_000) {
+			t.Errorf("set has wrong content")
+		}
+	})
+	t.Run("can handle values at the limits of the type", func(t *testing.T) {
+		var s set.IntSet[int8]
+		for i := math.MaxInt8; i >= math.MinInt8; i-- {
+			s.Add(int8(i))
+		}
+		if !s.IsDense() || s.Size() != 256 || !s.Contains(math.MinInt8) || !s.Contains(math.MaxInt8) {
+			t.Errorf("set has wrong content")
+		}
+		var s2 set.IntSet[int8]
+		for i := -100; i < 28; i++ {
+			s2.Add(int8(i))
+		}
+		s2.Add(math.MinInt8)
+		if !s2.IsDense() || s2.Size() != 129 || !s2.Contains(math.MinInt8) || s2.Contains(-101) {
+			t.Errorf("set has wrong content")
+		}
+		var u set.IntSet[uint64]
+		for i := range uint64(100) {
+			u.Add(math.MaxUint64 - i)
+		}
+		for i := range uint64(100) {
+			u.Add(math.MaxUint64 - 100 - i)
+		}
+		if !u.IsDense() || u.Size() != 200 || !u.Contains(math.MaxUint64) || !u.Contains(math.MaxUint64-199) {
+			t.Errorf("set has wrong content")
+		}
+		var w set.IntSet[uint8]
+		for i := 255; i >= 0; i-- {
+			w.Add(uint8(i))
+		}
+		if !w.IsDense() || w.Size() != 256 || !w.Contains(0) {
+			t.Errorf("set has wrong content")
+		}
+	})
+	t.Run("should behave like a set for many random operations", func(t *testing.T) {
+		r := rand.New(rand.NewPCG(1, 2))
+		var s set.IntSet[int64]
+		var want set.Set[int64]
+		for round := range 20 {
+			// alternate between dense blocks and scattered values
+			for range 2000 {
+				var v int64
+				if round%2 == 0 {
+					v = int64(r.IntN(3000)) - 1500
+				} else {
+					v = r.Int64N(1<<50) - 1<<49
+				}
+				switch r.IntN(4) {
+				case 0:
+					if got, want := s.Delete(v), want.Delete(v); got != want {
+						t.Fatalf("delete: got %v, wanted %v", got, want)
+					}
+				default:
+					s.Add(v)
+					want.Add(v)
+				}
+				if s.Contains(v) != want.Contains(v) {
+					t.Fatalf("contains %v: got %v, wanted %v", v, s.Contains(v), want.Contains(v))
+				}
+			}
+			if got := set.Collect(s.All()); !got.Equal(want) || s.Size() != want.Size() {
+				t.Fatalf("round %d: got %d elements, wanted %d", round, got.Size(), want.Size())
+			}
+		}
+	})
+	t.Run("can clear", func(t *testing.T) {
+		var s set.IntSet[int]
+		for i := range 100 {
+			s.Add(i)
+		}
+		s.Clear()
+		if s.Size() != 0 || s.Contains(1) || s.IsDense() {
+			t.Errorf("set was not cleared")
+		}
+	})
+	t.Run("can stop iteration early", func(t *testing.T) {
+		var s set.IntSet[int]
+		for i := range 100 {
+			s.Add(i)
+		}
+		var n int
+		for range s.All() {
+			n++
+			break
+		}
+		if n != 1 {
+			t.Errorf("got %v, wanted 1", n)
+		}
+	})
+}
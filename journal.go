@@ -0,0 +1,207 @@
+package set
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// JournalOp is the operation of a journal entry.
+type JournalOp uint8
+
+// Operations of journal entries.
+const (
+	JournalAdd JournalOp = iota + 1
+	JournalDelete
+)
+
+var journalOpNames = map[JournalOp]string{
+	JournalAdd:    "add",
+	JournalDelete: "delete",
+}
+
+// String returns the name of operation op, e.g. "add".
+func (op JournalOp) String() string {
+	if s, ok := journalOpNames[op]; ok {
+		return s
+	}
+	return fmt.Sprintf("JournalOp(%d)", op)
+}
+
+// MarshalText returns the name of operation op.
+// It returns an error for invalid operations.
+func (op JournalOp) MarshalText() ([]byte, error) {
+	s, ok := journalOpNames[op]
+	if !ok {
+		return nil, fmt.Errorf("%w operation %d", ErrInvalidJournal, op)
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText parses the name of an operation and replaces op.
+func (op *JournalOp) UnmarshalText(b []byte) error {
+	for k, v := range journalOpNames {
+		if v == string(b) {
+			*op = k
+			return nil
+		}
+	}
+	return fmt.Errorf("%w operation %q", ErrInvalidJournal, b)
+}
+
+// A JournalEntry records a change of a [Journal].
+type JournalEntry[E comparable] struct {
+	Seq     uint64    `json:"seq"` // sequence number starting at 1
+	Op      JournalOp `json:"op"`
+	Element E         `json:"element"`
+	Time    time.Time `json:"time"`
+}
+
+// A Journal is a set, which records every change in an append-only journal.
+//
+// Only changes are recorded, e.g. adding an element which is already in the set creates no entry.
+// The entries can be exported with [Journal.Entries], e.g. for storing them as JSON,
+// and imported again with [LoadJournal].
+// Replaying the entries allows reconstructing the elements of the set at any point in its history.
+//
+// Entries are timestamped with the system clock or with a Clock given to [NewJournal].
+// Timestamps never decrease: when the clock goes back in time,
+// new entries get the timestamp of the latest entry.
+//
+// The zero value of a Journal is an empty set with an empty journal ready to use.
+// Journal is not safe for concurrent use.
+type Journal[E comparable] struct {
+	s       Set[E]
+	entries []JournalEntry[E]
+	clock   Clock
+}
+
+// NewJournal returns a new empty journal, which timestamps entries with the current time of clock.
+// If clock is nil, the system clock is used.
+func NewJournal[E comparable](clock Clock) *Journal[E] {
+	return &Journal[E]{clock: clock}
+}
+
+// LoadJournal returns a new journal with the entries and the set which results from replaying them.
+// New entries are timestamped with the system clock.
+// It returns an error if the entries are not numbered consecutively, starting at 1,
+// have decreasing timestamps or contain invalid operations.
+func LoadJournal[E comparable](entries []JournalEntry[E]) (*Journal[E], error) {
+	return LoadJournalClock(entries, nil)
+}
+
+// LoadJournalClock is like [LoadJournal],
+// but the new journal timestamps entries with the current time of clock.
+// If clock is nil, the system clock is used.
+func LoadJournalClock[E comparable](entries []JournalEntry[E], clock Clock) (*Journal[E], error) {
+	j := &Journal[E]{clock: clock}
+	for i, e := range entries {
+		if e.Seq != uint64(i)+1 {
+			return nil, fmt.Errorf("%w entry %d: got sequence number %d, wanted %d", ErrInvalidJournal, i, e.Seq, i+1)
+		}
+		if _, ok := journalOpNames[e.Op]; !ok {
+			return nil, fmt.Errorf("%w entry %d: invalid operation %d", ErrInvalidJournal, i, e.Op)
+		}
+		if i > 0 && e.Time.Before(entries[i-1].Time) {
+			return nil, fmt.Errorf("%w entry %d: time %v is before time of previous entry", ErrInvalidJournal, i, e.Time)
+		}
+		j.s.apply(e)
+	}
+	j.entries = slices.Clone(entries)
+	return j, nil
+}
+
+// Add adds elements v to journal j and records an entry for each added element.
+func (j *Journal[E]) Add(v ...E) {
+	for _, x := range v {
+		if !j.s.Contains(x) {
+			j.record(JournalAdd, x)
+		}
+	}
+}
+
+// Contains reports whether element v is currently in journal j.
+func (j *Journal[E]) Contains(v E) bool {
+	return j.s.Contains(v)
+}
+
+// Delete removes elements v from journal j and records an entry for each removed element.
+// It returns the number of deleted elements.
+// Elements that are not found in the set are ignored.
+func (j *Journal[E]) Delete(v ...E) int {
+	var c int
+	for _, x := range v {
+		if j.s.Contains(x) {
+			j.record(JournalDelete, x)
+			c++
+		}
+	}
+	return c
+}
+
+// Entries returns a copy of all entries of journal j in the order they were recorded.
+func (j *Journal[E]) Entries() []JournalEntry[E] {
+	return slices.Clone(j.entries)
+}
+
+// Set returns a new set with the current elements of journal j.
+func (j *Journal[E]) Set() Set[E] {
+	return j.s.Clone()
+}
+
+// SetAt returns a new set with the elements of journal j right after the entry with sequence number seq.
+// A sequence number of 0 returns an empty set
+// and sequence numbers after the last entry return the current elements.
+func (j *Journal[E]) SetAt(seq uint64) Set[E] {
+	var r Set[E]
+	for _, e := range j.entries {
+		if e.Seq > seq {
+			break
+		}
+		r.apply(e)
+	}
+	return r
+}
+
+// SetAtTime returns a new set with the elements of journal j at time t,
+// which includes all changes recorded at or before t.
+// It relies on the timestamps of the entries never decreasing.
+func (j *Journal[E]) SetAtTime(t time.Time) Set[E] {
+	var r Set[E]
+	for _, e := range j.entries {
+		if e.Time.After(t) {
+			break
+		}
+		r.apply(e)
+	}
+	return r
+}
+
+// Size returns the number of elements currently in journal j.
+func (j *Journal[E]) Size() int {
+	return j.s.Size()
+}
+
+func (j *Journal[E]) record(op JournalOp, v E) {
+	e := JournalEntry[E]{
+		Seq:     uint64(len(j.entries)) + 1,
+		Op:      op,
+		Element: v,
+		Time:    clockNow(j.clock),
+	}
+	if n := len(j.entries); n > 0 && e.Time.Before(j.entries[n-1].Time) {
+		e.Time = j.entries[n-1].Time
+	}
+	j.entries = append(j.entries, e)
+	j.s.apply(e)
+}
+
+// apply applies the change of journal entry e to set s.
+func (s *Set[E]) apply(e JournalEntry[E]) {
+	switch e.Op {
+	case JournalAdd:
+		s.Add(e.Element)
+	case JournalDelete:
+		s.Delete(e.Element)
+	}
+}
@@ -0,0 +1,227 @@
+package set_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestJournal(t *testing.T) {
+	t.Run("should record changes", func(t *testing.T) {
+		var j set.Journal[string]
+		j.Add("a", "b", "a")
+		if got := j.Delete("a", "x"); got != 1 {
+			t.Errorf("got %v, wanted 1", got)
+		}
+		j.Add("c")
+		entries := j.Entries()
+		want := []struct {
+			op set.JournalOp
+			v  string
+		}{
+			{set.JournalAdd, "a"},
+			{set.JournalAdd, "b"},
+			{set.JournalDelete, "a"},
+			{set.JournalAdd, "c"},
+		}
+		if len(entries) != len(want) {
+			t.Fatalf("got %d entries, wanted %d", len(entries), len(want))
+		}
+		for i, e := range entries {
+			if e.Seq != uint64(i)+1 || e.Op != want[i].op || e.Element != want[i].v || e.Time.IsZero() {
+				t.Errorf("%d: got %+v, wanted %v %q", i, e, want[i].op, want[i].v)
+			}
+		}
+		if got, want := j.Set(), set.Of("b", "c"); !got.Equal(want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+		if !j.Contains("b") || j.Contains("a") || j.Size() != 2 {
+			t.Errorf("got wrong elements")
+		}
+	})
+	t.Run("can reconstruct the set at any entry", func(t *testing.T) {
+		var j set.Journal[int]
+		j.Add(1, 2)
+		j.Delete(1)
+		j.Add(3)
+		cases := []struct {
+			seq  uint64
+			want set.Set[int]
+		}{
+			{0, set.Of[int]()},
+			{1, set.Of(1)},
+			{2, set.Of(1, 2)},
+			{3, set.Of(2)},
+			{4, set.Of(2, 3)},
+			{100, set.Of(2, 3)},
+		}
+		for _, tc := range cases {
+			if got := j.SetAt(tc.seq); !got.Equal(tc.want) {
+				t.Errorf("%d: got %q, wanted %q", tc.seq, got, tc.want)
+			}
+		}
+	})
+	t.Run("can reconstruct the set at any time", func(t *testing.T) {
+		t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		j, err := set.LoadJournal([]set.JournalEntry[int]{
+			{Seq: 1, Op: set.JournalAdd, Element: 1, Time: t1},
+			{Seq: 2, Op: set.JournalAdd, Element: 2, Time: t1.Add(time.Hour)},
+			{Seq: 3, Op: set.JournalDelete, Element: 1, Time: t1.Add(2 * time.Hour)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cases := []struct {
+			t    time.Time
+			want set.Set[int]
+		}{
+			{t1.Add(-time.Minute), set.Of[int]()},
+			{t1, set.Of(1)},
+			{t1.Add(90 * time.Minute), set.Of(1, 2)},
+			{t1.Add(3 * time.Hour), set.Of(2)},
+		}
+		for _, tc := range cases {
+			if got := j.SetAtTime(tc.t); !got.Equal(tc.want) {
+				t.Errorf("%v: got %q, wanted %q", tc.t, got, tc.want)
+			}
+		}
+	})
+	t.Run("should timestamp entries with clock", func(t *testing.T) {
+		c := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+		j := set.NewJournal[int](c)
+		j.Add(1)
+		c.Advance(time.Hour)
+		j.Delete(1)
+		entries := j.Entries()
+		if got, want := entries[1].Time.Sub(entries[0].Time), time.Hour; got != want {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+		if got := j.SetAtTime(c.Now().Add(-time.Minute)); !got.Equal(set.Of(1)) {
+			t.Errorf("got %q, wanted {1}", got)
+		}
+	})
+	t.Run("should not decrease timestamps when clock goes back", func(t *testing.T) {
+		c := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+		j := set.NewJournal[int](c)
+		j.Add(1)
+		c.Advance(-time.Hour)
+		j.Add(2)
+		entries := j.Entries()
+		if !entries[1].Time.Equal(entries[0].Time) {
+			t.Errorf("got %v, wanted %v", entries[1].Time, entries[0].Time)
+		}
+		if got := j.SetAtTime(entries[0].Time); !got.Equal(set.Of(1, 2)) {
+			t.Errorf("got %q, wanted {1 2}", got)
+		}
+	})
+	t.Run("can load journal with clock", func(t *testing.T) {
+		t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		c := &fakeClock{t: t1.Add(time.Hour)}
+		j, err := set.LoadJournalClock([]set.JournalEntry[int]{
+			{Seq: 1, Op: set.JournalAdd, Element: 1, Time: t1},
+		}, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		j.Add(2)
+		if got := j.Entries()[1].Time; !got.Equal(c.Now()) {
+			t.Errorf("got %v, wanted %v", got, c.Now())
+		}
+		if got := j.SetAtTime(t1); !got.Equal(set.Of(1)) {
+			t.Errorf("got %q, wanted {1}", got)
+		}
+	})
+	t.Run("can export and import journal as JSON", func(t *testing.T) {
+		var j set.Journal[string]
+		j.Add("a", "b")
+		j.Delete("a")
+		b, err := json.Marshal(j.Entries())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var entries []set.JournalEntry[string]
+		if err := json.Unmarshal(b, &entries); err != nil {
+			t.Fatal(err)
+		}
+		j2, err := set.LoadJournal(entries)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := j2.Set(), set.Of("b"); !got.Equal(want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+		j2.Add("c")
+		if got := j2.Entries()[3].Seq; got != 4 {
+			t.Errorf("got %v, wanted 4", got)
+		}
+	})
+	t.Run("should not share entries", func(t *testing.T) {
+		entries := []set.JournalEntry[int]{{Seq: 1, Op: set.JournalAdd, Element: 1}}
+		j, err := set.LoadJournal(entries)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[0].Element = 2
+		j.Entries()[0].Element = 3
+		if got := j.Entries()[0].Element; got != 1 {
+			t.Errorf("got %v, wanted 1", got)
+		}
+	})
+	t.Run("should reject invalid entries", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			entries []set.JournalEntry[int]
+		}{
+			{"gap in sequence", []set.JournalEntry[int]{{Seq: 1, Op: set.JournalAdd}, {Seq: 3, Op: set.JournalAdd}}},
+			{"not starting at 1", []set.JournalEntry[int]{{Seq: 0, Op: set.JournalAdd}}},
+			{"invalid operation", []set.JournalEntry[int]{{Seq: 1, Op: 0}}},
+			{"decreasing time", []set.JournalEntry[int]{
+				{Seq: 1, Op: set.JournalAdd, Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+				{Seq: 2, Op: set.JournalAdd, Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := set.LoadJournal(tc.entries)
+				if err == nil {
+					t.Errorf("got %q, wanted error", err)
+				}
+			})
+		}
+	})
+}
+
+func TestJournalOp(t *testing.T) {
+	t.Run("can convert to and from text", func(t *testing.T) {
+		for _, op := range []set.JournalOp{set.JournalAdd, set.JournalDelete} {
+			b, err := op.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != op.String() {
+				t.Errorf("got %q, wanted %q", b, op.String())
+			}
+			var got set.JournalOp
+			if err := got.UnmarshalText(b); err != nil {
+				t.Fatal(err)
+			}
+			if got != op {
+				t.Errorf("got %v, wanted %v", got, op)
+			}
+		}
+	})
+	t.Run("should handle invalid operations", func(t *testing.T) {
+		op := set.JournalOp(99)
+		if got, want := op.String(), "JournalOp(99)"; got != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+		if _, err := op.MarshalText(); err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+		if err := op.UnmarshalText([]byte("update")); err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package set
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Lenient is a [Set] with a lenient JSON decoding,
+// which also accepts a single JSON value instead of an array
+// and unmarshals it into a set with one element.
+// For example the JSON documents ["foo"] and "foo" both unmarshal into the set {foo}.
+// Note that JSON arrays are always unmarshaled as sets, even if the element type is an array.
+//
+// Lenient embeds a Set and can therefore be used like a Set, e.g. as field of a response payload.
+// The zero value of a Lenient is an empty set ready to use.
+type Lenient[E comparable] struct {
+	Set[E]
+}
+
+// UnmarshalJSON parses the JSON-encoded data b and replaces the current set.
+// JSON values, which are not arrays, will be unmarshaled into a set with one element.
+// JSON null values will be unmarshaled into a zero set.
+// The elements of an existing set are replaced in place like for [Set.UnmarshalJSON].
+func (s *Lenient[E]) UnmarshalJSON(b []byte) error {
+	t := bytes.Trim(b, " \t\r\n")
+	if len(t) > 0 && t[0] == '[' || bytes.Equal(t, []byte("null")) {
+		return s.Set.UnmarshalJSON(b)
+	}
+	var v E
+	err := json.Unmarshal(b, &v)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	s.replace([]E{v})
+	return nil
+}
+
+// NonNull is a [Set] which is converted to an empty JSON array instead of JSON null when it is a zero set.
+// This allows sending sets to consumers which don't accept null values
+// without having to initialize every set.
+//
+// NonNull embeds a Set and can therefore be used like a Set, e.g. as field of a response payload.
+// The zero value of a NonNull is an empty set ready to use.
+type NonNull[E comparable] struct {
+	Set[E]
+}
+
+// AppendText appends the JSON encoding of set s to b and returns the extended buffer.
+// The encoding is the same as for [NonNull.MarshalJSON].
+//
+// AppendText implements the encoding.TextAppender interface.
+func (s NonNull[E]) AppendText(b []byte) ([]byte, error) {
+	if s.m == nil {
+		return append(b, "[]"...), nil
+	}
+	return s.Set.AppendText(b)
+}
+
+// MarshalJSON returns the JSON encoding of the set.
+// Sets are converted to JSON arrays. Zero sets will be converted into empty JSON arrays.
+func (s NonNull[E]) MarshalJSON() ([]byte, error) {
+	return s.AppendText(nil)
+}
+
+// Strict is a [Set] with a strict JSON decoding,
+// which rejects JSON arrays with duplicate elements instead of silently collapsing them.
+// Elements are also considered duplicates when they are only equal after decoding,
+// e.g. for element types which normalize their values when unmarshaled.
+//
+// Strict embeds a Set and can therefore be used like a Set, e.g. as field of a request payload.
+// The zero value of a Strict is an empty set ready to use.
+type Strict[E comparable] struct {
+	Set[E]
+}
+
+// UnmarshalJSON parses the JSON-encoded data b and replaces the current set.
+// It returns an error if the JSON array contains duplicate elements.
+// JSON null values will be unmarshaled into a zero set.
+// The elements of an existing set are replaced in place like for [Set.UnmarshalJSON].
+func (s *Strict[E]) UnmarshalJSON(b []byte) error {
+	v, err := unmarshalJSONElements[E](b)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		s.m = nil
+		return nil
+	}
+	r := make(map[E]struct{}, len(v))
+	for _, x := range v {
+		if _, ok := r[x]; ok {
+			return fmt.Errorf("%w in JSON array: %v", ErrDuplicateElement, x)
+		}
+		r[x] = struct{}{}
+	}
+	if s.m == nil {
+		s.m = r
+		return nil
+	}
+	s.replace(v)
+	return nil
+}
+
+// DecodeJSON decodes the next JSON array from dec and returns its elements as a new set.
+// JSON null values will be returned as zero set.
+//
+// Elements are added to the set while they are decoded,
+// which allows decoding large JSON arrays without holding the JSON document
+// or an intermediate slice of all elements in memory.
+// A stream of JSON arrays can be decoded by calling DecodeJSON repeatedly with the same decoder.
+// At the end of the stream the returned error wraps [io.EOF].
+//
+// Errors for invalid data wrap [ErrInvalidEncoding],
+// while errors from reading the input of dec are returned as they are.
+func DecodeJSON[E comparable](dec *json.Decoder) (Set[E], error) {
+	var s Set[E]
+	t, err := dec.Token()
+	if err != nil {
+		return s, wrapJSONError(err)
+	}
+	if t == nil {
+		return s, nil
+	}
+	if t != json.Delim('[') {
+		return s, fmt.Errorf("%w: expected JSON array, got %v", ErrInvalidEncoding, t)
+	}
+	s.m = make(map[E]struct{})
+	var raw json.RawMessage // reused for all elements
+	for dec.More() {
+		// decode the raw element first, so that read errors can be told apart from invalid elements
+		if err := dec.Decode(&raw); err != nil {
+			return Set[E]{}, wrapJSONError(err)
+		}
+		var v E
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return Set[E]{}, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+		}
+		s.m[v] = struct{}{}
+	}
+	if _, err := dec.Token(); err != nil { // closing bracket
+		return Set[E]{}, wrapJSONError(err)
+	}
+	return s, nil
+}
+
+// unmarshalJSONElements parses the JSON array b and returns its elements.
+// It returns a nil slice for JSON null values.
+// The elements are unmarshaled in one call, which is faster than decoding them one by one.
+func unmarshalJSONElements[E comparable](b []byte) ([]E, error) {
+	var v []E
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	return v, nil
+}
+
+// wrapJSONError returns error err of a JSON decoder wrapped with [ErrInvalidEncoding],
+// unless it has been caused by reading the input.
+func wrapJSONError(err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || err == io.EOF || err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	return err
+}
@@ -0,0 +1,296 @@
+package set_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestNonNull(t *testing.T) {
+	cases := []struct {
+		name string
+		in   set.NonNull[int]
+		want string
+	}{
+		{"zero set", set.NonNull[int]{}, "[]"},
+		{"empty set", set.NonNull[int]{Set: set.Of[int]()}, "[]"},
+		{"non-empty set", set.NonNull[int]{Set: set.Of(1)}, "[1]"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := json.Marshal(tc.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+	t.Run("should append empty JSON array for zero sets", func(t *testing.T) {
+		got, err := set.NonNull[int]{}.AppendText([]byte("x"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "x[]"; string(got) != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+	t.Run("should return empty JSON array as database value for zero sets", func(t *testing.T) {
+		got, err := set.NonNull[int]{}.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "[]"; got != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+	t.Run("can be used as struct field", func(t *testing.T) {
+		var payload struct {
+			Tags set.NonNull[string] `json:"tags"`
+		}
+		got, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"tags":[]}`; string(got) != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+	t.Run("can unmarshal", func(t *testing.T) {
+		var s set.NonNull[int]
+		if err := json.Unmarshal([]byte("[1,2]"), &s); err != nil {
+			t.Fatal(err)
+		}
+		if want := set.Of(1, 2); !s.Equal(want) {
+			t.Errorf("got %q, wanted %q", s, want)
+		}
+	})
+}
+
+func TestStrict(t *testing.T) {
+	cases := []struct {
+		name      string
+		in        string
+		want      set.Set[int]
+		wantZero  bool
+		wantError bool
+	}{
+		{"no duplicates", "[1,2,3]", set.Of(1, 2, 3), false, false},
+		{"empty array", "[]", set.Of[int](), false, false},
+		{"null", "null", set.Set[int]{}, true, false},
+		{"duplicates", "[1,2,1]", set.Set[int]{}, false, true},
+		{"invalid JSON", "[1,", set.Set[int]{}, false, true},
+		{"invalid element", `["a"]`, set.Set[int]{}, false, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := set.Strict[int]{Set: set.Of(9)}
+			err := json.Unmarshal([]byte(tc.in), &s)
+			if tc.wantError {
+				if err == nil {
+					t.Errorf("got %q, wanted error", err)
+				}
+				if !s.Equal(set.Of(9)) {
+					t.Errorf("set was changed on error: %q", s)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got %q, wanted no error", err)
+			}
+			if !s.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", s, tc.want)
+			}
+			if s.IsZero() != tc.wantZero {
+				t.Errorf("got zero %v, wanted %v", s.IsZero(), tc.wantZero)
+			}
+		})
+	}
+	t.Run("should replace elements in place for copies of the set", func(t *testing.T) {
+		a := set.Strict[int]{Set: set.Of(1, 2)}
+		b := a
+		if err := json.Unmarshal([]byte("[3]"), &a); err != nil {
+			t.Fatal(err)
+		}
+		if want := set.Of(3); !a.Equal(want) || !b.Equal(want) {
+			t.Errorf("got %q and %q, wanted %q", a, b, want)
+		}
+	})
+	t.Run("should reject duplicates when scanning", func(t *testing.T) {
+		s := set.Strict[int]{Set: set.Of(9)}
+		err := s.Scan("[1,1]")
+		if !errors.Is(err, set.ErrDuplicateElement) {
+			t.Errorf("got %q, wanted %q", err, set.ErrDuplicateElement)
+		}
+		if want := set.Of(9); !s.Equal(want) {
+			t.Errorf("got %q, wanted unchanged set %q", s, want)
+		}
+		if err := s.Scan(nil); err != nil || !s.IsZero() {
+			t.Errorf("got %q and error %q, wanted zero set", s, err)
+		}
+	})
+	t.Run("should reject data after the array", func(t *testing.T) {
+		var s set.Strict[int]
+		err := s.UnmarshalJSON([]byte("[1] [2]"))
+		if !errors.Is(err, set.ErrInvalidEncoding) {
+			t.Errorf("got %q, wanted %q", err, set.ErrInvalidEncoding)
+		}
+	})
+	t.Run("should reject elements which are equal after decoding", func(t *testing.T) {
+		var s set.Strict[caseless]
+		if err := json.Unmarshal([]byte(`["a","A"]`), &s); err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+	})
+	t.Run("can be used as struct field", func(t *testing.T) {
+		var payload struct {
+			IDs set.Strict[int] `json:"ids"`
+		}
+		if err := json.Unmarshal([]byte(`{"ids":[1,2,2]}`), &payload); err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+		if err := json.Unmarshal([]byte(`{"ids":[1,2]}`), &payload); err != nil {
+			t.Fatal(err)
+		}
+		if !payload.IDs.Contains(2) {
+			t.Errorf("got %q, wanted to contain 2", payload.IDs)
+		}
+		b, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(b); got != `{"ids":[1,2]}` && got != `{"ids":[2,1]}` {
+			t.Errorf("got %q", got)
+		}
+	})
+}
+
+// caseless is a string which is normalized to lower case when unmarshaled.
+type caseless string
+
+func (c *caseless) UnmarshalText(b []byte) error {
+	*c = caseless(strings.ToLower(string(b)))
+	return nil
+}
+
+func TestDecodeJSON(t *testing.T) {
+	cases := []struct {
+		name      string
+		in        string
+		want      set.Set[int]
+		wantZero  bool
+		wantError bool
+	}{
+		{"multiple elements", "[1,2,3]", set.Of(1, 2, 3), false, false},
+		{"duplicates", "[1,2,1]", set.Of(1, 2), false, false},
+		{"empty array", "[]", set.Of[int](), false, false},
+		{"null", "null", set.Set[int]{}, true, false},
+		{"empty document", "", set.Set[int]{}, false, true},
+		{"no array", `{"a":1}`, set.Set[int]{}, false, true},
+		{"invalid element", `[1,"a"]`, set.Set[int]{}, false, true},
+		{"incomplete array", "[1,2", set.Set[int]{}, false, true},
+		{"invalid closing bracket", "[1}", set.Set[int]{}, false, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := set.DecodeJSON[int](json.NewDecoder(strings.NewReader(tc.in)))
+			if tc.wantError {
+				if err == nil {
+					t.Errorf("got %q, wanted error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got %q, wanted no error", err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+			if got.IsZero() != tc.wantZero {
+				t.Errorf("got zero %v, wanted %v", got.IsZero(), tc.wantZero)
+			}
+		})
+	}
+	t.Run("can decode a stream of JSON arrays", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader("[1,2] [3] null"))
+		for _, want := range []set.Set[int]{set.Of(1, 2), set.Of(3), {}} {
+			got, err := set.DecodeJSON[int](dec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(want) || got.IsZero() != want.IsZero() {
+				t.Errorf("got %q, wanted %q", got, want)
+			}
+		}
+		_, err := set.DecodeJSON[int](dec)
+		if !errors.Is(err, io.EOF) {
+			t.Errorf("got %q, wanted %q", err, io.EOF)
+		}
+	})
+}
+
+func TestLenient(t *testing.T) {
+	cases := []struct {
+		name      string
+		in        string
+		want      set.Set[string]
+		wantZero  bool
+		wantError bool
+	}{
+		{"array", `["a","b"]`, set.Of("a", "b"), false, false},
+		{"array with whitespace", " \n[\"a\"]", set.Of("a"), false, false},
+		{"empty array", `[]`, set.Of[string](), false, false},
+		{"scalar", `"a"`, set.Of("a"), false, false},
+		{"scalar with whitespace", ` "a" `, set.Of("a"), false, false},
+		{"null", `null`, set.Set[string]{}, true, false},
+		{"invalid scalar", `1`, set.Set[string]{}, false, true},
+		{"invalid array", `[1]`, set.Set[string]{}, false, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var s set.Lenient[string]
+			err := json.Unmarshal([]byte(tc.in), &s)
+			if tc.wantError {
+				if err == nil {
+					t.Errorf("got %q, wanted error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got %q, wanted no error", err)
+			}
+			if !s.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", s, tc.want)
+			}
+			if s.IsZero() != tc.wantZero {
+				t.Errorf("got zero %v, wanted %v", s.IsZero(), tc.wantZero)
+			}
+		})
+	}
+	t.Run("should replace elements in place for copies of the set", func(t *testing.T) {
+		a := set.Lenient[string]{Set: set.Of("a", "b")}
+		b := a
+		if err := json.Unmarshal([]byte(`"c"`), &a); err != nil {
+			t.Fatal(err)
+		}
+		if want := set.Of("c"); !a.Equal(want) || !b.Equal(want) {
+			t.Errorf("got %q and %q, wanted %q", a, b, want)
+		}
+	})
+	t.Run("can be used as struct field", func(t *testing.T) {
+		var payload struct {
+			Tags set.Lenient[string] `json:"tags"`
+		}
+		if err := json.Unmarshal([]byte(`{"tags":"foo"}`), &payload); err != nil {
+			t.Fatal(err)
+		}
+		if want := set.Of("foo"); !payload.Tags.Equal(want) {
+			t.Errorf("got %q, wanted %q", payload.Tags, want)
+		}
+	})
+}
@@ -0,0 +1,21 @@
+package labels_test
+
+import (
+	"fmt"
+
+	"github.com/ErikKalkoken/go-set/labels"
+)
+
+func Example() {
+	l, err := labels.Parse("app=web,env=prod,tier=frontend")
+	if err != nil {
+		panic(err)
+	}
+	sel := labels.MustParseSelector("env in (prod,staging),!canary")
+	fmt.Println(l.Matches(sel))
+	sel = labels.MustParseSelector("tier!=frontend")
+	fmt.Println(l.Matches(sel))
+	// Output:
+	// true
+	// false
+}
@@ -0,0 +1,116 @@
+// Package labels provides sets of key/value labels and selectors for matching them.
+//
+// Labels and selectors follow the syntax and semantics used by Kubernetes,
+// e.g. the selector "env in (prod,staging),tier!=frontend,!canary"
+// matches all label sets with an env label of either prod or staging,
+// a tier label which is not frontend and no canary label.
+package labels
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Labels is a set of key/value labels, where each key has exactly one value.
+//
+// The zero value of Labels is an empty set ready to use.
+// Labels is not safe for concurrent use.
+type Labels struct {
+	m map[string]string
+}
+
+// FromMap returns new labels with the key/value pairs of m.
+func FromMap(m map[string]string) Labels {
+	return Labels{m: maps.Clone(m)}
+}
+
+// Parse parses labels in the form "key1=value1,key2=value2".
+// It returns an error when a label is malformed or a key appears more than once.
+func Parse(s string) (Labels, error) {
+	var l Labels
+	if strings.TrimSpace(s) == "" {
+		return l, nil
+	}
+	for _, p := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(p, "=")
+		k = strings.TrimSpace(k)
+		v = strings.TrimSpace(v)
+		if !ok || !isValidToken(k) || k == "" || !isValidToken(v) {
+			return Labels{}, fmt.Errorf("labels: invalid label %q", p)
+		}
+		if l.Has(k) {
+			return Labels{}, fmt.Errorf("labels: duplicate key %q", k)
+		}
+		l.Set(k, v)
+	}
+	return l, nil
+}
+
+// All returns an iterator over all key/value pairs of l.
+//
+// Note that the order of the labels is undefined.
+func (l Labels) All() iter.Seq2[string, string] {
+	return maps.All(l.m)
+}
+
+// Delete removes the label with key from l.
+func (l Labels) Delete(key string) {
+	delete(l.m, key)
+}
+
+// Get returns the value of the label with key and reports whether it exists.
+func (l Labels) Get(key string) (string, bool) {
+	v, ok := l.m[key]
+	return v, ok
+}
+
+// Has reports whether l has a label with key.
+func (l Labels) Has(key string) bool {
+	_, ok := l.m[key]
+	return ok
+}
+
+// Matches reports whether labels l are matched by selector sel.
+func (l Labels) Matches(sel Selector) bool {
+	return sel.Matches(l)
+}
+
+// Set sets the value of the label with key, replacing any previous value.
+func (l *Labels) Set(key, value string) {
+	if l.m == nil {
+		l.m = make(map[string]string)
+	}
+	l.m[key] = value
+}
+
+// Size returns the number of labels in l.
+func (l Labels) Size() int {
+	return len(l.m)
+}
+
+// String returns the labels in the form "key1=value1,key2=value2" sorted by key.
+func (l Labels) String() string {
+	p := make([]string, 0, len(l.m))
+	for _, k := range slices.Sorted(maps.Keys(l.m)) {
+		p = append(p, k+"="+l.m[k])
+	}
+	return strings.Join(p, ",")
+}
+
+// isValidToken reports whether s only contains characters permitted in keys and values.
+func isValidToken(s string) bool {
+	for _, r := range s {
+		if !isTokenRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isTokenRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		r == '-' || r == '_' || r == '.' || r == '/'
+}
@@ -0,0 +1,82 @@
+package labels_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set/labels"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in        string
+		want      map[string]string
+		wantError bool
+	}{
+		{"a=1,b=2", map[string]string{"a": "1", "b": "2"}, false},
+		{" a = 1 , b=", map[string]string{"a": "1", "b": ""}, false},
+		{"app.kubernetes.io/name=web", map[string]string{"app.kubernetes.io/name": "web"}, false},
+		{"", map[string]string{}, false},
+		{"a", nil, true},
+		{"=1", nil, true},
+		{"a=1,a=2", nil, true},
+		{"a b=1", nil, true},
+		{"a=1 2", nil, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := labels.Parse(tc.in)
+			if tc.wantError {
+				if err == nil {
+					t.Errorf("got %q, wanted error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got %q, wanted no error", err)
+			}
+			if m := maps.Collect(got.All()); !maps.Equal(m, tc.want) {
+				t.Errorf("got %v, wanted %v", m, tc.want)
+			}
+		})
+	}
+}
+
+func TestLabels(t *testing.T) {
+	t.Run("can set and get labels", func(t *testing.T) {
+		var l labels.Labels
+		l.Set("a", "1")
+		l.Set("a", "2")
+		l.Set("b", "")
+		if v, ok := l.Get("a"); !ok || v != "2" {
+			t.Errorf("got %q %v, wanted 2 true", v, ok)
+		}
+		if _, ok := l.Get("c"); ok {
+			t.Errorf("got %v, wanted false", ok)
+		}
+		if !l.Has("b") || l.Has("c") {
+			t.Errorf("has reported wrong result")
+		}
+		if l.Size() != 2 {
+			t.Errorf("got %v, wanted 2", l.Size())
+		}
+		l.Delete("a")
+		if l.Has("a") {
+			t.Errorf("label not deleted")
+		}
+	})
+	t.Run("can create from map without sharing it", func(t *testing.T) {
+		m := map[string]string{"a": "1"}
+		l := labels.FromMap(m)
+		l.Set("b", "2")
+		if len(m) != 1 {
+			t.Errorf("map was changed")
+		}
+	})
+	t.Run("can convert to string", func(t *testing.T) {
+		l := labels.FromMap(map[string]string{"b": "2", "a": "1"})
+		if got, want := l.String(), "a=1,b=2"; got != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,208 @@
+package labels
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+// A Selector selects labels by a list of requirements, which must all be satisfied.
+//
+// The following requirements are supported:
+//
+//   - key: the label exists
+//   - !key: the label does not exist
+//   - key=value or key==value: the label exists and has the value
+//   - key!=value: the label does not exist or has a different value
+//   - key in (value1,value2): the label exists and has one of the values
+//   - key notin (value1,value2): the label does not exist or has none of the values
+//
+// The zero value of a Selector matches all labels.
+type Selector struct {
+	requirements []requirement
+}
+
+type operator int
+
+const (
+	opExists operator = iota
+	opDoesNotExist
+	opEquals
+	opNotEquals
+	opIn
+	opNotIn
+)
+
+type requirement struct {
+	key    string
+	op     operator
+	values set.Set[string]
+}
+
+func (r requirement) matches(l Labels) bool {
+	v, ok := l.Get(r.key)
+	switch r.op {
+	case opDoesNotExist:
+		return !ok
+	case opEquals, opIn:
+		return ok && r.values.Contains(v)
+	case opNotEquals, opNotIn:
+		return !ok || !r.values.Contains(v)
+	}
+	return ok
+}
+
+func (r requirement) String() string {
+	values := slices.Sorted(r.values.All())
+	switch r.op {
+	case opDoesNotExist:
+		return "!" + r.key
+	case opEquals:
+		return r.key + "=" + values[0]
+	case opNotEquals:
+		return r.key + "!=" + values[0]
+	case opIn:
+		return r.key + " in (" + strings.Join(values, ",") + ")"
+	case opNotIn:
+		return r.key + " notin (" + strings.Join(values, ",") + ")"
+	}
+	return r.key
+}
+
+// ParseSelector parses s as a selector, e.g. "env in (prod,staging),tier!=frontend".
+// An empty string is parsed into a selector which matches all labels.
+func ParseSelector(s string) (Selector, error) {
+	p := &parser{s: s}
+	var sel Selector
+	p.skipSpaces()
+	if p.done() {
+		return sel, nil
+	}
+	for {
+		r, err := p.parseRequirement()
+		if err != nil {
+			return Selector{}, fmt.Errorf("labels: invalid selector %q: %w", s, err)
+		}
+		sel.requirements = append(sel.requirements, r)
+		p.skipSpaces()
+		if p.done() {
+			return sel, nil
+		}
+		if !p.consume(",") {
+			return Selector{}, fmt.Errorf("labels: invalid selector %q: expected \",\" at position %d", s, p.pos)
+		}
+	}
+}
+
+// MustParseSelector is like [ParseSelector] but panics if s can not be parsed.
+func MustParseSelector(s string) Selector {
+	sel, err := ParseSelector(s)
+	if err != nil {
+		panic(err)
+	}
+	return sel
+}
+
+// Matches reports whether labels l satisfy all requirements of selector sel.
+func (sel Selector) Matches(l Labels) bool {
+	for _, r := range sel.requirements {
+		if !r.matches(l) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the canonical string representation of selector sel.
+func (sel Selector) String() string {
+	p := make([]string, 0, len(sel.requirements))
+	for _, r := range sel.requirements {
+		p = append(p, r.String())
+	}
+	return strings.Join(p, ",")
+}
+
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *parser) skipSpaces() {
+	for !p.done() && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *parser) consume(prefix string) bool {
+	if strings.HasPrefix(p.s[p.pos:], prefix) {
+		p.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (p *parser) token() string {
+	start := p.pos
+	for !p.done() && isTokenRune(rune(p.s[p.pos])) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func (p *parser) parseRequirement() (requirement, error) {
+	var r requirement
+	p.skipSpaces()
+	if p.consume("!") {
+		r.op = opDoesNotExist
+		p.skipSpaces()
+	}
+	r.key = p.token()
+	if r.key == "" {
+		return r, fmt.Errorf("expected key at position %d", p.pos)
+	}
+	if r.op == opDoesNotExist {
+		return r, nil
+	}
+	p.skipSpaces()
+	switch {
+	case p.done() || strings.HasPrefix(p.s[p.pos:], ","):
+		r.op = opExists
+		return r, nil
+	case p.consume("!="):
+		r.op = opNotEquals
+	case p.consume("=="), p.consume("="):
+		r.op = opEquals
+	case p.consume("in"):
+		r.op = opIn
+	case p.consume("notin"):
+		r.op = opNotIn
+	default:
+		return r, fmt.Errorf("expected operator at position %d", p.pos)
+	}
+	p.skipSpaces()
+	if r.op == opEquals || r.op == opNotEquals {
+		r.values = set.Of(p.token())
+		return r, nil
+	}
+	if !p.consume("(") {
+		return r, fmt.Errorf("expected \"(\" at position %d", p.pos)
+	}
+	r.values = set.Of[string]()
+	for {
+		p.skipSpaces()
+		r.values.Add(p.token())
+		p.skipSpaces()
+		if p.consume(")") {
+			return r, nil
+		}
+		if !p.consume(",") {
+			return r, fmt.Errorf("expected \",\" or \")\" at position %d", p.pos)
+		}
+	}
+}
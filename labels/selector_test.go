@@ -0,0 +1,101 @@
+package labels_test
+
+import (
+	"testing"
+
+	"github.com/ErikKalkoken/go-set/labels"
+)
+
+func TestSelector_Matches(t *testing.T) {
+	l := labels.FromMap(map[string]string{"env": "prod", "tier": "backend", "empty": ""})
+	cases := []struct {
+		selector string
+		want     bool
+	}{
+		{"", true},
+		{"env", true},
+		{"canary", false},
+		{"!canary", true},
+		{"!env", false},
+		{"env=prod", true},
+		{"env==prod", true},
+		{"env=dev", false},
+		{"empty=", true},
+		{"env!=dev", true},
+		{"env!=prod", false},
+		{"canary!=true", true},
+		{"env in (prod,staging)", true},
+		{"env in (dev, staging)", false},
+		{"canary in (true)", false},
+		{"env notin (dev,staging)", true},
+		{"env notin (prod)", false},
+		{"canary notin (true)", true},
+		{"env=prod,tier=backend", true},
+		{"env=prod, tier=frontend", false},
+		{" env in (prod) , !canary ", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.selector, func(t *testing.T) {
+			sel, err := labels.ParseSelector(tc.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := l.Matches(sel); got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+	t.Run("zero selector matches everything", func(t *testing.T) {
+		var sel labels.Selector
+		if !sel.Matches(l) {
+			t.Errorf("got false, wanted true")
+		}
+	})
+}
+
+func TestParseSelector(t *testing.T) {
+	t.Run("should return error for invalid selectors", func(t *testing.T) {
+		cases := []string{
+			",",
+			"env,",
+			"!",
+			"env prod",
+			"env=prod tier=backend",
+			"env in prod",
+			"env in (prod",
+			"env in (prod staging)",
+			"env > 1",
+		}
+		for _, s := range cases {
+			if _, err := labels.ParseSelector(s); err == nil {
+				t.Errorf("%q: got %q, wanted error", s, err)
+			}
+		}
+	})
+	t.Run("can return canonical string", func(t *testing.T) {
+		cases := []struct {
+			in   string
+			want string
+		}{
+			{"env", "env"},
+			{"! env", "!env"},
+			{"env == prod", "env=prod"},
+			{"env!=prod", "env!=prod"},
+			{"env in (b, a)", "env in (a,b)"},
+			{"env notin (b,a),x", "env notin (a,b),x"},
+		}
+		for _, tc := range cases {
+			if got := labels.MustParseSelector(tc.in).String(); got != tc.want {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		}
+	})
+	t.Run("should panic on invalid selector", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic when it was expected to")
+			}
+		}()
+		labels.MustParseSelector("env in")
+	})
+}
@@ -0,0 +1,165 @@
+package set
+
+// AddMapKeys adds the keys of map m to set s.
+//
+// This is a function and not a method of [Set], because methods can not have type parameters.
+func AddMapKeys[M ~map[E]V, E comparable, V any](s *Set[E], m M) {
+	if s.m == nil {
+		s.m = make(map[E]struct{}, len(m))
+	}
+	p := beginWrite(s.m, "AddMapKeys")
+	for k := range m {
+		s.m[k] = struct{}{}
+	}
+	endWrite(p)
+}
+
+// AddMapValues adds the values of map m to set s.
+//
+// This is a function and not a method of [Set], because methods can not have type parameters.
+func AddMapValues[M ~map[K]E, K comparable, E comparable](s *Set[E], m M) {
+	if s.m == nil {
+		s.m = make(map[E]struct{}, len(m))
+	}
+	guardWrite(s.m, "AddMapValues", func() {
+		for _, v := range m {
+			s.m[v] = struct{}{}
+		}
+	})
+}
+
+// FromMap returns a new set with the keys of map m.
+// This allows converting maps used for representing sets, e.g. of type map[E]struct{},
+// including named map types like the sets of other packages.
+// If m is nil, the result is a zero set.
+//
+// Sets of packages, which are not based on maps, can be converted through slices instead,
+// e.g. set.Of(d.ToSlice()...) for a set d of github.com/deckarep/golang-set.
+func FromMap[M ~map[E]V, E comparable, V any](m M) Set[E] {
+	var r Set[E]
+	if m == nil {
+		return r
+	}
+	r.m = make(map[E]struct{}, len(m))
+	for k := range m {
+		r.m[k] = struct{}{}
+	}
+	return r
+}
+
+// FromMapBool returns a new set with the keys of map m, which have the value true.
+// This allows using maps of bools as commonly used for representing sets.
+// If m is nil, the result is a zero set.
+func FromMapBool[E comparable](m map[E]bool) Set[E] {
+	var r Set[E]
+	if m == nil {
+		return r
+	}
+	r.m = make(map[E]struct{})
+	for k, v := range m {
+		if v {
+			r.m[k] = struct{}{}
+		}
+	}
+	return r
+}
+
+// KeysDifference returns a new set with the keys of map m1, which are not keys of map m2.
+// This allows treating the keys of maps as sets without converting them to sets first.
+func KeysDifference[M1 ~map[E]V1, M2 ~map[E]V2, E comparable, V1, V2 any](m1 M1, m2 M2) Set[E] {
+	r := Set[E]{m: make(map[E]struct{})}
+	for k := range m1 {
+		if _, ok := m2[k]; !ok {
+			r.m[k] = struct{}{}
+		}
+	}
+	return r
+}
+
+// KeysIntersect returns a new set with the keys, which are in both map m1 and map m2.
+// This allows treating the keys of maps as sets without converting them to sets first.
+func KeysIntersect[M1 ~map[E]V1, M2 ~map[E]V2, E comparable, V1, V2 any](m1 M1, m2 M2) Set[E] {
+	r := Set[E]{m: make(map[E]struct{})}
+	if len(m1) <= len(m2) {
+		for k := range m1 {
+			if _, ok := m2[k]; ok {
+				r.m[k] = struct{}{}
+			}
+		}
+	} else {
+		for k := range m2 {
+			if _, ok := m1[k]; ok {
+				r.m[k] = struct{}{}
+			}
+		}
+	}
+	return r
+}
+
+// KeysUnion returns a new set with the keys of map m1 and map m2.
+// This allows treating the keys of maps as sets without converting them to sets first.
+func KeysUnion[M1 ~map[E]V1, M2 ~map[E]V2, E comparable, V1, V2 any](m1 M1, m2 M2) Set[E] {
+	r := Set[E]{m: make(map[E]struct{}, max(len(m1), len(m2)))}
+	for k := range m1 {
+		r.m[k] = struct{}{}
+	}
+	for k := range m2 {
+		r.m[k] = struct{}{}
+	}
+	return r
+}
+
+// SubMap returns a new map with the entries of map m, whose keys are in set s.
+// If m is nil, the result is a nil map.
+func SubMap[M ~map[E]V, E comparable, V any](m M, s Set[E]) M {
+	if m == nil {
+		return nil
+	}
+	r := make(M)
+	if len(m) <= len(s.m) {
+		for k, v := range m {
+			if _, ok := s.m[k]; ok {
+				r[k] = v
+			}
+		}
+	} else {
+		for k := range s.m {
+			if v, ok := m[k]; ok {
+				r[k] = v
+			}
+		}
+	}
+	return r
+}
+
+// ToMap returns a new map of type M with the elements of set s as keys and zero values.
+// This allows converting sets to maps used for representing sets, e.g. of type map[E]struct{},
+// including named map types like the sets of other packages.
+// If s is a zero set, the result is a nil map.
+//
+// Sets of packages, which are not based on maps, can be created from a slice instead,
+// e.g. mapset.NewSet(slices.Collect(s.All())...) for github.com/deckarep/golang-set.
+func ToMap[M ~map[E]V, E comparable, V any](s Set[E]) M {
+	if s.m == nil {
+		return nil
+	}
+	m := make(M, len(s.m))
+	for v := range s.m {
+		var z V
+		m[v] = z
+	}
+	return m
+}
+
+// ToMapBool returns a new map with the elements of set s as keys and true as values.
+// If s is a zero set, the result is a nil map.
+func ToMapBool[E comparable](s Set[E]) map[E]bool {
+	if s.m == nil {
+		return nil
+	}
+	m := make(map[E]bool, len(s.m))
+	for v := range s.m {
+		m[v] = true
+	}
+	return m
+}
@@ -0,0 +1,243 @@
+package set_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestAddMapKeys(t *testing.T) {
+	type scores map[string]int
+	cases := []struct {
+		name string
+		s    set.Set[string]
+		m    scores
+		want set.Set[string]
+	}{
+		{"zero set", set.Set[string]{}, scores{"a": 1, "b": 2}, set.Of("a", "b")},
+		{"non-empty set", set.Of("a", "c"), scores{"a": 1, "b": 2}, set.Of("a", "b", "c")},
+		{"empty map", set.Of("a"), scores{}, set.Of("a")},
+		{"nil map", set.Of("a"), nil, set.Of("a")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			set.AddMapKeys(&tc.s, tc.m)
+			if !tc.s.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", tc.s, tc.want)
+			}
+		})
+	}
+}
+
+func TestAddMapValues(t *testing.T) {
+	cases := []struct {
+		name string
+		s    set.Set[int]
+		m    map[string]int
+		want set.Set[int]
+	}{
+		{"zero set", set.Set[int]{}, map[string]int{"a": 1, "b": 2, "c": 1}, set.Of(1, 2)},
+		{"non-empty set", set.Of(1, 3), map[string]int{"a": 1, "b": 2}, set.Of(1, 2, 3)},
+		{"empty map", set.Of(1), map[string]int{}, set.Of(1)},
+		{"nil map", set.Of(1), nil, set.Of(1)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			set.AddMapValues(&tc.s, tc.m)
+			if !tc.s.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", tc.s, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	type strings map[string]struct{}
+	cases := []struct {
+		name     string
+		m        strings
+		want     set.Set[string]
+		wantZero bool
+	}{
+		{"multiple keys", strings{"a": {}, "b": {}}, set.Of("a", "b"), false},
+		{"empty map", strings{}, set.Of[string](), false},
+		{"nil map", nil, set.Of[string](), true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := set.FromMap(tc.m)
+			if !got.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+			if got.IsZero() != tc.wantZero {
+				t.Errorf("got zero %v, wanted %v", got.IsZero(), tc.wantZero)
+			}
+		})
+	}
+}
+
+func TestFromMapBool(t *testing.T) {
+	cases := []struct {
+		name     string
+		m        map[string]bool
+		want     set.Set[string]
+		wantZero bool
+	}{
+		{"true values", map[string]bool{"a": true, "b": true}, set.Of("a", "b"), false},
+		{"mixed values", map[string]bool{"a": true, "b": false}, set.Of("a"), false},
+		{"false values", map[string]bool{"a": false}, set.Of[string](), false},
+		{"empty map", map[string]bool{}, set.Of[string](), false},
+		{"nil map", nil, set.Set[string]{}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := set.FromMapBool(tc.m)
+			if !got.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+			if got.IsZero() != tc.wantZero {
+				t.Errorf("got zero %v, wanted %v", got.IsZero(), tc.wantZero)
+			}
+		})
+	}
+}
+
+func TestKeysDifference(t *testing.T) {
+	cases := []struct {
+		name string
+		m1   map[string]int
+		m2   map[string]bool
+		want set.Set[string]
+	}{
+		{"overlapping", map[string]int{"a": 1, "b": 2}, map[string]bool{"b": true, "c": false}, set.Of("a")},
+		{"disjoint", map[string]int{"a": 1}, map[string]bool{"b": true}, set.Of("a")},
+		{"first empty", map[string]int{}, map[string]bool{"b": true}, set.Of[string]()},
+		{"second nil", map[string]int{"a": 1}, nil, set.Of("a")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := set.KeysDifference(tc.m1, tc.m2)
+			if !got.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKeysIntersect(t *testing.T) {
+	cases := []struct {
+		name string
+		m1   map[string]int
+		m2   map[string]bool
+		want set.Set[string]
+	}{
+		{"first smaller", map[string]int{"b": 2}, map[string]bool{"a": true, "b": true}, set.Of("b")},
+		{"second smaller", map[string]int{"a": 1, "b": 2}, map[string]bool{"b": true}, set.Of("b")},
+		{"disjoint", map[string]int{"a": 1}, map[string]bool{"b": true}, set.Of[string]()},
+		{"nil", nil, map[string]bool{"b": true}, set.Of[string]()},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := set.KeysIntersect(tc.m1, tc.m2)
+			if !got.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKeysUnion(t *testing.T) {
+	cases := []struct {
+		name string
+		m1   map[string]int
+		m2   map[string]bool
+		want set.Set[string]
+	}{
+		{"overlapping", map[string]int{"a": 1, "b": 2}, map[string]bool{"b": true, "c": false}, set.Of("a", "b", "c")},
+		{"first empty", map[string]int{}, map[string]bool{"b": true}, set.Of("b")},
+		{"both nil", nil, nil, set.Of[string]()},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := set.KeysUnion(tc.m1, tc.m2)
+			if !got.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSubMap(t *testing.T) {
+	type scores map[string]int
+	cases := []struct {
+		name string
+		m    scores
+		s    set.Set[string]
+		want scores
+	}{
+		{"smaller map", scores{"a": 1, "b": 2}, set.Of("b", "c", "d"), scores{"b": 2}},
+		{"smaller set", scores{"a": 1, "b": 2, "c": 3}, set.Of("b", "d"), scores{"b": 2}},
+		{"empty set", scores{"a": 1}, set.Of[string](), scores{}},
+		{"zero set", scores{"a": 1}, set.Set[string]{}, scores{}},
+		{"nil map", nil, set.Of("a"), nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := set.SubMap(tc.m, tc.s)
+			if !maps.Equal(got, tc.want) {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+			if (got == nil) != (tc.want == nil) {
+				t.Errorf("got nil %v, wanted %v", got == nil, tc.want == nil)
+			}
+		})
+	}
+}
+
+func TestToMap(t *testing.T) {
+	type strings map[string]struct{}
+	cases := []struct {
+		name string
+		s    set.Set[string]
+		want strings
+	}{
+		{"multiple elements", set.Of("a", "b"), strings{"a": {}, "b": {}}},
+		{"empty set", set.Of[string](), strings{}},
+		{"zero set", set.Set[string]{}, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := set.ToMap[strings](tc.s)
+			if !maps.Equal(got, tc.want) {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+			if (got == nil) != (tc.want == nil) {
+				t.Errorf("got nil %v, wanted %v", got == nil, tc.want == nil)
+			}
+		})
+	}
+}
+
+func TestToMapBool(t *testing.T) {
+	cases := []struct {
+		name string
+		s    set.Set[string]
+		want map[string]bool
+	}{
+		{"multiple elements", set.Of("a", "b"), map[string]bool{"a": true, "b": true}},
+		{"empty set", set.Of[string](), map[string]bool{}},
+		{"zero set", set.Set[string]{}, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := set.ToMapBool(tc.s)
+			if !maps.Equal(got, tc.want) {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+			if (got == nil) != (tc.want == nil) {
+				t.Errorf("got nil %v, wanted %v", got == nil, tc.want == nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package set
+
+import (
+	"cmp"
+	"iter"
+)
+
+// MergeSorted returns an iterator over the elements of the sorted sequences seqs
+// in ascending order without duplicates.
+// Each sequence must be sorted in ascending order, but may contain duplicates.
+//
+// Only the next element of each sequence is kept in memory,
+// so that sorted outputs of shards can be merged without building a set.
+// Each element takes logarithmic time in the number of sequences.
+func MergeSorted[E cmp.Ordered](seqs ...iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		var h mergeHeap[E]
+		for _, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			if v, ok := next(); ok {
+				h = append(h, mergeCursor[E]{v, next})
+			}
+		}
+		h.init()
+		var last E
+		for i := 0; len(h) > 0; i++ {
+			v := h[0].v
+			if i == 0 || cmp.Compare(v, last) != 0 {
+				if !yield(v) {
+					return
+				}
+				last = v
+			}
+			if x, ok := h[0].next(); ok {
+				h[0].v = x
+			} else {
+				h[0] = h[len(h)-1]
+				h = h[:len(h)-1]
+			}
+			h.down(0)
+		}
+	}
+}
+
+type mergeCursor[E cmp.Ordered] struct {
+	v    E
+	next func() (E, bool)
+}
+
+// mergeHeap is a binary min heap of cursors ordered by their current element.
+type mergeHeap[E cmp.Ordered] []mergeCursor[E]
+
+func (h mergeHeap[E]) init() {
+	for i := len(h)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+}
+
+func (h mergeHeap[E]) down(i int) {
+	for {
+		c := 2*i + 1
+		if c >= len(h) {
+			return
+		}
+		if r := c + 1; r < len(h) && cmp.Less(h[r].v, h[c].v) {
+			c = r
+		}
+		if !cmp.Less(h[c].v, h[i].v) {
+			return
+		}
+		h[i], h[c] = h[c], h[i]
+		i = c
+	}
+}
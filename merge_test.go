@@ -0,0 +1,60 @@
+package set_test
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestMergeSorted(t *testing.T) {
+	cases := []struct {
+		name string
+		seqs [][]int
+		want []int
+	}{
+		{"no sequences", nil, nil},
+		{"empty sequences", [][]int{{}, {}}, nil},
+		{"one sequence", [][]int{{1, 1, 2, 3, 3}}, []int{1, 2, 3}},
+		{"disjoint", [][]int{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}}, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{"overlapping", [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, []int{1, 2, 3, 4, 5}},
+		{"different lengths", [][]int{{5}, {}, {1, 2, 3, 4, 5, 6}}, []int{1, 2, 3, 4, 5, 6}},
+		{"negative", [][]int{{-3, 0}, {-5, -3}}, []int{-5, -3, 0}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var seqs []iter.Seq[int]
+			for _, s := range tc.seqs {
+				seqs = append(seqs, slices.Values(s))
+			}
+			got := slices.Collect(set.MergeSorted(seqs...))
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+	t.Run("can stop iterating", func(t *testing.T) {
+		var got []string
+		for v := range set.MergeSorted(slices.Values([]string{"a", "c"}), slices.Values([]string{"b"})) {
+			got = append(got, v)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if want := []string{"a", "b"}; !slices.Equal(got, want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+	t.Run("should match sorted union of sets", func(t *testing.T) {
+		a := set.Range(0, 1000, 3)
+		b := set.Range(0, 1000, 5)
+		c := set.Range(500, 1500, 7)
+		got := slices.Collect(set.MergeSorted(slices.Values(slices.Sorted(a.All())),
+			slices.Values(slices.Sorted(b.All())), slices.Values(slices.Sorted(c.All()))))
+		want := slices.Sorted(set.Union(a, b, c).All())
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package mmapset_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/ErikKalkoken/go-set/mmapset"
+)
+
+func Example() {
+	dir, err := os.MkdirTemp("", "mmapset")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "blocklist.bin")
+
+	// build the set offline
+	blocked := set.Of(sha256.Sum256([]byte("spam")), sha256.Sum256([]byte("scam")))
+	f, err := os.Create(path)
+	if err != nil {
+		panic(err)
+	}
+	if err := mmapset.Write(f, blocked.All()); err != nil {
+		panic(err)
+	}
+	if err := f.Close(); err != nil {
+		panic(err)
+	}
+
+	// query the set in each process
+	s, err := mmapset.Open[[32]byte](path)
+	if err != nil {
+		panic(err)
+	}
+	defer s.Close()
+	fmt.Println(s.Contains(sha256.Sum256([]byte("spam"))))
+	fmt.Println(s.Contains(sha256.Sum256([]byte("ham"))))
+	// Output:
+	// true
+	// false
+}
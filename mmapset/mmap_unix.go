@@ -0,0 +1,30 @@
+//go:build unix
+
+package mmapset
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapFile maps the file at path into memory
+// and returns the mapped bytes and a function for releasing them.
+func mapFile(path string) ([]byte, func() error, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	if fi.Size() == 0 {
+		return nil, func() error { return nil }, nil
+	}
+	b, err := syscall.Mmap(int(f.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, &os.PathError{Op: "mmap", Path: path, Err: err}
+	}
+	return b, func() error { return syscall.Munmap(b) }, nil
+}
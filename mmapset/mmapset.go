@@ -0,0 +1,152 @@
+// Package mmapset provides read-only sets of fixed-size keys, which are stored in files
+// and memory-mapped for queries.
+//
+// Sets are built offline with [Write] and opened with [Open].
+// The keys of an opened set are not loaded into the Go heap,
+// but are paged in by the operating system when accessed.
+// This allows using huge static sets, e.g. a blocklist of hashes,
+// without increasing the memory usage of each process.
+// On systems without memory-mapping the file is read into memory instead.
+//
+// The file format consists of a header with a magic number, the key size and the number of keys,
+// which is followed by all keys sorted in ascending order.
+package mmapset
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"sort"
+	"unsafe"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+const (
+	magic      = "GOSETMM1"
+	headerSize = len(magic) + 16
+)
+
+var errInvalidFormat = errors.New("mmapset: invalid file format")
+
+// Write writes a set with the keys from seq to w in the file format of this package.
+// Duplicate keys are written only once.
+//
+// All keys are kept in memory for sorting them before writing.
+func Write[K set.FixedKey](w io.Writer, seq iter.Seq[K]) error {
+	keys := slices.SortedFunc(seq, func(a, b K) int {
+		return bytes.Compare(keyBytes(&a), keyBytes(&b))
+	})
+	keys = slices.Compact(keys)
+	bw := bufio.NewWriter(w)
+	var h [headerSize]byte
+	copy(h[:], magic)
+	binary.LittleEndian.PutUint64(h[len(magic):], uint64(unsafe.Sizeof(*new(K))))
+	binary.LittleEndian.PutUint64(h[len(magic)+8:], uint64(len(keys)))
+	bw.Write(h[:]) // errors are sticky and returned by later writes
+	for i := range keys {
+		if _, err := bw.Write(keyBytes(&keys[i])); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// A Set is a read-only set of fixed-size keys, which is backed by a memory-mapped file.
+//
+// A Set must be closed with [Set.Close] when it is no longer used.
+// Set is safe for concurrent use, but must not be used after it was closed.
+type Set[K set.FixedKey] struct {
+	data    []byte // keys without header
+	n       int
+	release func() error
+}
+
+// Open opens the set stored in the file at path.
+// It returns an error if the file is not in the format of this package
+// or was written for keys of a different size.
+func Open[K set.FixedKey](path string) (*Set[K], error) {
+	b, release, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := newSet[K](b)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	s.release = release
+	return s, nil
+}
+
+func newSet[K set.FixedKey](b []byte) (*Set[K], error) {
+	if len(b) < headerSize || string(b[:len(magic)]) != magic {
+		return nil, errInvalidFormat
+	}
+	size := binary.LittleEndian.Uint64(b[len(magic):])
+	if want := uint64(unsafe.Sizeof(*new(K))); size != want {
+		return nil, fmt.Errorf("mmapset: got keys of size %d, wanted %d", size, want)
+	}
+	n := binary.LittleEndian.Uint64(b[len(magic)+8:])
+	data := b[headerSize:]
+	if n != uint64(len(data))/size || uint64(len(data))%size != 0 {
+		return nil, errInvalidFormat
+	}
+	return &Set[K]{data: data, n: int(n)}, nil
+}
+
+// All returns an iterator over all keys of set s in ascending order.
+func (s *Set[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for i := range s.n {
+			if !yield(s.key(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Close closes set s and releases the mapped memory.
+func (s *Set[K]) Close() error {
+	s.data, s.n = nil, 0
+	if s.release == nil {
+		return nil
+	}
+	err := s.release()
+	s.release = nil
+	return err
+}
+
+// Contains reports whether key k is in set s.
+func (s *Set[K]) Contains(k K) bool {
+	b := keyBytes(&k)
+	i := sort.Search(s.n, func(i int) bool {
+		return bytes.Compare(s.keyBytes(i), b) >= 0
+	})
+	return i < s.n && bytes.Equal(s.keyBytes(i), b)
+}
+
+// Size returns the number of keys in set s.
+func (s *Set[K]) Size() int {
+	return s.n
+}
+
+func (s *Set[K]) key(i int) K {
+	var k K
+	copy(keyBytes(&k), s.keyBytes(i))
+	return k
+}
+
+func (s *Set[K]) keyBytes(i int) []byte {
+	size := int(unsafe.Sizeof(*new(K)))
+	return s.data[i*size : (i+1)*size]
+}
+
+func keyBytes[K set.FixedKey](k *K) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(k)), unsafe.Sizeof(*k))
+}
@@ -0,0 +1,191 @@
+package mmapset_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/ErikKalkoken/go-set/mmapset"
+)
+
+type hash [8]byte
+
+func writeSet(t *testing.T, keys ...hash) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := mmapset.Write(&buf, slices.Values(keys)); err != nil {
+		t.Fatal(err)
+	}
+	return writeFile(t, buf.Bytes())
+}
+
+func writeFile(t *testing.T, b []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "set.bin")
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSet(t *testing.T) {
+	keys := []hash{{3}, {1}, {2, 1}, {1}, {0, 0, 0, 0, 0, 0, 0, 9}}
+	path := writeSet(t, keys...)
+	s, err := mmapset.Open[hash](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	t.Run("should contain all keys", func(t *testing.T) {
+		if got := s.Size(); got != 4 {
+			t.Errorf("got %v, wanted 4", got)
+		}
+		for _, k := range keys {
+			if !s.Contains(k) {
+				t.Errorf("expected %v to be contained", k)
+			}
+		}
+		for _, k := range []hash{{}, {2}, {4}, {255, 255}} {
+			if s.Contains(k) {
+				t.Errorf("expected %v to not be contained", k)
+			}
+		}
+	})
+	t.Run("should iterate keys in ascending order", func(t *testing.T) {
+		got := slices.Collect(s.All())
+		want := []hash{{0, 0, 0, 0, 0, 0, 0, 9}, {1}, {2, 1}, {3}}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+	t.Run("can stop iterating", func(t *testing.T) {
+		var n int
+		for range s.All() {
+			n++
+			break
+		}
+		if n != 1 {
+			t.Errorf("got %v, wanted 1", n)
+		}
+	})
+}
+
+func TestSet_Close(t *testing.T) {
+	s, err := mmapset.Open[hash](writeSet(t, hash{1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if s.Size() != 0 || s.Contains(hash{1}) {
+		t.Errorf("wanted closed set to be empty")
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("got %q, wanted no error when closing again", err)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("can open empty sets", func(t *testing.T) {
+		s, err := mmapset.Open[hash](writeSet(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+		if s.Size() != 0 || s.Contains(hash{}) {
+			t.Errorf("wanted empty set")
+		}
+	})
+	t.Run("can open sets built from sets", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := mmapset.Write(&buf, set.Of(hash{1}, hash{2}).All()); err != nil {
+			t.Fatal(err)
+		}
+		s, err := mmapset.Open[hash](writeFile(t, buf.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+		if s.Size() != 2 {
+			t.Errorf("got %v, wanted 2", s.Size())
+		}
+	})
+	t.Run("should return error for missing files", func(t *testing.T) {
+		_, err := mmapset.Open[hash](filepath.Join(t.TempDir(), "missing.bin"))
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("got %q, wanted %q", err, os.ErrNotExist)
+		}
+	})
+	t.Run("should return error for directories", func(t *testing.T) {
+		_, err := mmapset.Open[hash](t.TempDir())
+		if err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+	})
+	t.Run("should return error for invalid files", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := mmapset.Write(&buf, slices.Values([]hash{{1}})); err != nil {
+			t.Fatal(err)
+		}
+		valid := buf.Bytes()
+		cases := []struct {
+			name string
+			b    []byte
+		}{
+			{"empty file", []byte{}},
+			{"too short", valid[:10]},
+			{"invalid magic", append([]byte("XXXXXXXX"), valid[8:]...)},
+			{"truncated keys", valid[:len(valid)-1]},
+			{"trailing data", append(slices.Clone(valid), make([]byte, 8)...)},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := mmapset.Open[hash](writeFile(t, tc.b))
+				if err == nil {
+					t.Errorf("got %q, wanted error", err)
+				}
+			})
+		}
+	})
+	t.Run("should return error for different key sizes", func(t *testing.T) {
+		_, err := mmapset.Open[[16]byte](writeSet(t, hash{1}))
+		if err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+	})
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("should return write errors", func(t *testing.T) {
+		keys := make([]hash, 1000)
+		for i := range keys {
+			keys[i] = hash{byte(i), byte(i >> 8)}
+		}
+		for _, n := range []int{0, 1} {
+			err := mmapset.Write(&errWriter{n: n}, slices.Values(keys))
+			if !errors.Is(err, errTest) {
+				t.Errorf("got %q, wanted %q", err, errTest)
+			}
+		}
+	})
+}
+
+var errTest = errors.New("test error")
+
+// errWriter fails after n successful writes.
+type errWriter struct {
+	n int
+}
+
+func (w *errWriter) Write(b []byte) (int, error) {
+	if w.n == 0 {
+		return 0, errTest
+	}
+	w.n--
+	return len(b), nil
+}
@@ -0,0 +1,51 @@
+package set
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// CollectNDJSON reads newline-delimited JSON values from r
+// and returns them as elements of a new set.
+// Empty lines are ignored.
+//
+// When a value can not be decoded, the returned error wraps [ErrInvalidEncoding]
+// and contains the line number.
+func CollectNDJSON[E comparable](r io.Reader) (Set[E], error) {
+	var s Set[E]
+	br := bufio.NewReader(r)
+	for n := 1; ; n++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return Set[E]{}, err
+		}
+		if b := bytes.TrimSpace(line); len(b) > 0 {
+			var v E
+			if err := json.Unmarshal(b, &v); err != nil {
+				return Set[E]{}, fmt.Errorf("%w: line %d: %w", ErrInvalidEncoding, n, err)
+			}
+			s.Add(v)
+		}
+		if err != nil {
+			return s, nil
+		}
+	}
+}
+
+// WriteNDJSON writes the elements of set s to w as newline-delimited JSON values.
+//
+// Note that the order of the elements is undefined.
+func WriteNDJSON[E comparable](w io.Writer, s Set[E]) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for v := range s.All() {
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,118 @@
+package set_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestCollectNDJSON(t *testing.T) {
+	cases := []struct {
+		name      string
+		in        string
+		want      set.Set[string]
+		wantError string
+	}{
+		{"multiple lines", "\"a\"\n\"b\"\n", set.Of("a", "b"), ""},
+		{"no trailing newline", "\"a\"\n\"b\"", set.Of("a", "b"), ""},
+		{"duplicates", "\"a\"\n\"a\"\n", set.Of("a"), ""},
+		{"empty lines", "\n\"a\"\r\n  \n\"b\"\n", set.Of("a", "b"), ""},
+		{"empty", "", set.Set[string]{}, ""},
+		{"invalid value", "\"a\"\n\n1\n", set.Set[string]{}, "set: invalid encoding: line 3: "},
+		{"invalid JSON", "\"a\n", set.Set[string]{}, "set: invalid encoding: line 1: "},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := set.CollectNDJSON[string](strings.NewReader(tc.in))
+			if tc.wantError != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tc.wantError) {
+					t.Errorf("got %q, wanted error starting with %q", err, tc.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got %q, wanted no error", err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+	t.Run("should return read errors", func(t *testing.T) {
+		_, err := set.CollectNDJSON[string](errReader{})
+		if !errors.Is(err, errTest) {
+			t.Errorf("got %q, wanted %q", err, errTest)
+		}
+	})
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	t.Run("should write one value per line", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := set.WriteNDJSON(&buf, set.Of("a", "b"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := strings.Split(buf.String(), "\n")
+		slices.Sort(got)
+		want := []string{"", `"a"`, `"b"`}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+	t.Run("should write nothing for empty sets", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := set.WriteNDJSON(&buf, set.Set[string]{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("got %q, wanted empty output", buf.String())
+		}
+	})
+	t.Run("can read written values", func(t *testing.T) {
+		var buf bytes.Buffer
+		s := set.Of(1, 2, 3)
+		if err := set.WriteNDJSON(&buf, s); err != nil {
+			t.Fatal(err)
+		}
+		got, err := set.CollectNDJSON[int](&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(s) {
+			t.Errorf("got %q, wanted %q", got, s)
+		}
+	})
+	t.Run("should return encoding errors", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := set.WriteNDJSON(&buf, set.Of(make(chan int)))
+		if err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+	})
+	t.Run("should return write errors", func(t *testing.T) {
+		err := set.WriteNDJSON(errWriter{}, set.Of(1))
+		if !errors.Is(err, errTest) {
+			t.Errorf("got %q, wanted %q", err, errTest)
+		}
+	})
+}
+
+var errTest = errors.New("test error")
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errTest
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errTest
+}
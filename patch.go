@@ -0,0 +1,32 @@
+package set
+
+// A Patch describes the changes between two sets
+// as the elements to be added and the elements to be removed.
+//
+// Patches are marshaled to JSON as objects with the two fields "add" and "remove",
+// which allows transmitting the changes of a set instead of the full set.
+type Patch[E comparable] struct {
+	Add    Set[E] `json:"add"`
+	Remove Set[E] `json:"remove"`
+}
+
+// MakePatch returns a [Patch], which transforms set old into set new.
+func MakePatch[E comparable](old, new Set[E]) Patch[E] {
+	p := Patch[E]{
+		Add:    Difference(new, old),
+		Remove: Difference(old, new),
+	}
+	return p
+}
+
+// ApplyPatch applies the changes of patch p to set s.
+// Elements are removed before elements are added.
+func ApplyPatch[E comparable](s *Set[E], p Patch[E]) {
+	s.DeleteSeq(p.Remove.All())
+	s.AddSeq(p.Add.All())
+}
+
+// IsEmpty reports whether patch p contains no changes.
+func (p Patch[E]) IsEmpty() bool {
+	return p.Add.Size() == 0 && p.Remove.Size() == 0
+}
@@ -0,0 +1,78 @@
+package set_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestPatch(t *testing.T) {
+	cases := []struct {
+		name       string
+		old        set.Set[int]
+		new        set.Set[int]
+		wantAdd    set.Set[int]
+		wantRemove set.Set[int]
+	}{
+		{"adds and removes", set.Of(1, 2, 3), set.Of(2, 3, 4), set.Of(4), set.Of(1)},
+		{"adds only", set.Of(1), set.Of(1, 2), set.Of(2), set.Of[int]()},
+		{"removes only", set.Of(1, 2), set.Of(1), set.Of[int](), set.Of(2)},
+		{"no changes", set.Of(1, 2), set.Of(1, 2), set.Of[int](), set.Of[int]()},
+		{"from empty", set.Of[int](), set.Of(1, 2), set.Of(1, 2), set.Of[int]()},
+		{"to empty", set.Of(1, 2), set.Of[int](), set.Of[int](), set.Of(1, 2)},
+		{"zero sets", set.Set[int]{}, set.Set[int]{}, set.Of[int](), set.Of[int]()},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := set.MakePatch(tc.old, tc.new)
+			if !p.Add.Equal(tc.wantAdd) {
+				t.Errorf("add: got %q, wanted %q", p.Add, tc.wantAdd)
+			}
+			if !p.Remove.Equal(tc.wantRemove) {
+				t.Errorf("remove: got %q, wanted %q", p.Remove, tc.wantRemove)
+			}
+			if got, want := p.IsEmpty(), tc.old.Equal(tc.new); got != want {
+				t.Errorf("is empty: got %v, wanted %v", got, want)
+			}
+			s := tc.old.Clone()
+			set.ApplyPatch(&s, p)
+			if !s.Equal(tc.new) {
+				t.Errorf("apply: got %q, wanted %q", s, tc.new)
+			}
+		})
+	}
+	t.Run("should add elements which are also removed", func(t *testing.T) {
+		s := set.Of(1, 2)
+		set.ApplyPatch(&s, set.Patch[int]{Add: set.Of(2), Remove: set.Of(1, 2)})
+		want := set.Of(2)
+		if !s.Equal(want) {
+			t.Errorf("got %q, wanted %q", s, want)
+		}
+	})
+	t.Run("can apply to zero set", func(t *testing.T) {
+		var s set.Set[int]
+		set.ApplyPatch(&s, set.Patch[int]{Add: set.Of(1), Remove: set.Of(2)})
+		want := set.Of(1)
+		if !s.Equal(want) {
+			t.Errorf("got %q, wanted %q", s, want)
+		}
+	})
+	t.Run("can marshal and unmarshal JSON", func(t *testing.T) {
+		p1 := set.MakePatch(set.Of(1, 2), set.Of(2, 3))
+		b, err := json.Marshal(p1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(b), `{"add":[3],"remove":[1]}`; got != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+		var p2 set.Patch[int]
+		if err := json.Unmarshal(b, &p2); err != nil {
+			t.Fatal(err)
+		}
+		if !p2.Add.Equal(p1.Add) || !p2.Remove.Equal(p1.Remove) {
+			t.Errorf("got %v, wanted %v", p2, p1)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package pathset_test
+
+import (
+	"fmt"
+
+	"github.com/ErikKalkoken/go-set/pathset"
+)
+
+func Example() {
+	s := pathset.New(pathset.Options{Backslash: true})
+	s.Add("./cmd/main.go", `internal\db\db.go`, "internal/db/db_test.go", "README.md")
+	fmt.Println(s.Contains("cmd//main.go"))
+	fmt.Println(s.UnderDir("internal"))
+	m, err := s.Matching("**/*_test.go")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(m)
+	// Output:
+	// true
+	// {internal/db/db.go internal/db/db_test.go}
+	// {internal/db/db_test.go}
+}
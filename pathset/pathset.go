@@ -0,0 +1,185 @@
+// Package pathset provides a set of file paths with normalization and glob queries.
+package pathset
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"path"
+	"strings"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+// Options configures how paths are normalized by a [Set].
+type Options struct {
+	// CaseInsensitive treats paths which only differ in case as the same path.
+	CaseInsensitive bool
+
+	// Backslash treats backslashes as path separators, e.g. for Windows paths.
+	Backslash bool
+}
+
+// A Set is a set of file paths.
+//
+// Paths are normalized when they are added or queried:
+// They are converted to forward slashes and cleaned with [path.Clean],
+// so that e.g. "./a/b" and "a//b/" are the same path "a/b".
+// When paths only differ in case and the set is case-insensitive,
+// the set keeps the spelling of the path that was added first.
+//
+// The zero value of a Set is an empty, case-sensitive set ready to use.
+// Set is not safe for concurrent use.
+type Set struct {
+	m    map[string]string // key to path
+	opts Options
+}
+
+// New returns a new empty set of paths, which uses opts for normalization.
+func New(opts Options) *Set {
+	return &Set{opts: opts}
+}
+
+// Of returns a new case-sensitive set of the paths v.
+func Of(v ...string) *Set {
+	s := &Set{}
+	s.Add(v...)
+	return s
+}
+
+// Add adds paths v to set s.
+func (s *Set) Add(v ...string) {
+	if s.m == nil {
+		s.m = make(map[string]string)
+	}
+	for _, p := range v {
+		p = s.Normalize(p)
+		k := s.key(p)
+		if _, ok := s.m[k]; !ok {
+			s.m[k] = p
+		}
+	}
+}
+
+// All returns on iterator over all normalized paths of set s.
+//
+// Note that the order of the paths is undefined.
+func (s *Set) All() iter.Seq[string] {
+	return maps.Values(s.m)
+}
+
+// Contains reports whether path v is in set s.
+func (s *Set) Contains(v string) bool {
+	_, ok := s.m[s.key(s.Normalize(v))]
+	return ok
+}
+
+// Delete removes paths v from set s.
+// It returns the number of deleted paths.
+func (s *Set) Delete(v ...string) int {
+	ln := len(s.m)
+	for _, p := range v {
+		delete(s.m, s.key(s.Normalize(p)))
+	}
+	return ln - len(s.m)
+}
+
+// Matching returns a new set with the paths of s, which match the glob pattern.
+//
+// The pattern syntax is the same as for [path.Match],
+// with the addition that a "**" path element matches zero or more path elements,
+// e.g. "src/**/*.go" matches "src/a.go" and "src/x/y/b.go".
+// The pattern is normalized the same way as paths.
+// It returns an error when the pattern is malformed.
+func (s *Set) Matching(pattern string) (set.Set[string], error) {
+	var r set.Set[string]
+	pattern = s.key(s.Normalize(pattern))
+	elems := strings.Split(pattern, "/")
+	for _, e := range elems {
+		if _, err := path.Match(e, ""); err != nil {
+			return r, fmt.Errorf("pathset: pattern %q: %w", pattern, err)
+		}
+	}
+	for k, p := range s.m {
+		if matchElements(elems, strings.Split(k, "/")) {
+			r.Add(p)
+		}
+	}
+	return r, nil
+}
+
+// Normalize returns the normalized form of path v as it would be stored in set s.
+func (s *Set) Normalize(v string) string {
+	if s.opts.Backslash {
+		v = strings.ReplaceAll(v, `\`, "/")
+	}
+	return path.Clean(v)
+}
+
+// Size returns the number of paths in set s.
+func (s *Set) Size() int {
+	return len(s.m)
+}
+
+// String returns a string representation of set s.
+// Paths are printed with curly brackets and sorted, e.g. {a b}.
+func (s *Set) String() string {
+	return set.Collect(s.All()).String()
+}
+
+// UnderDir returns a new set with the paths of s, which are inside directory dir.
+// The directory itself is included if it is in s.
+// The directory "." contains all relative paths, which do not start with "..".
+func (s *Set) UnderDir(dir string) set.Set[string] {
+	var r set.Set[string]
+	dir = s.key(s.Normalize(dir))
+	for k, p := range s.m {
+		if isUnder(k, dir) {
+			r.Add(p)
+		}
+	}
+	return r
+}
+
+func (s *Set) key(p string) string {
+	if s.opts.CaseInsensitive {
+		return strings.ToLower(p)
+	}
+	return p
+}
+
+func isUnder(p, dir string) bool {
+	if dir == "." {
+		return !path.IsAbs(p) && p != ".." && !strings.HasPrefix(p, "../")
+	}
+	if p == dir {
+		return true
+	}
+	if dir == "/" {
+		return path.IsAbs(p)
+	}
+	return strings.HasPrefix(p, dir+"/")
+}
+
+// matchElements reports whether the path elements match the pattern elements.
+func matchElements(pattern, elems []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(elems); i++ {
+				if matchElements(pattern[1:], elems[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(elems) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], elems[0]); !ok {
+			return false
+		}
+		pattern = pattern[1:]
+		elems = elems[1:]
+	}
+	return len(elems) == 0
+}
@@ -0,0 +1,160 @@
+package pathset_test
+
+import (
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/ErikKalkoken/go-set/pathset"
+)
+
+func TestSet_Add(t *testing.T) {
+	cases := []struct {
+		name string
+		opts pathset.Options
+		v    []string
+		want set.Set[string]
+	}{
+		{"cleans paths", pathset.Options{}, []string{"./a", "a//b/", "a/./c/../d"}, set.Of("a", "a/b", "a/d")},
+		{"collapses equal paths", pathset.Options{}, []string{"./a", "a", "a/"}, set.Of("a")},
+		{"keeps case", pathset.Options{}, []string{"a", "A"}, set.Of("a", "A")},
+		{"folds case", pathset.Options{CaseInsensitive: true}, []string{"A/b", "a/B"}, set.Of("A/b")},
+		{"keeps backslashes", pathset.Options{}, []string{`a\b`}, set.Of(`a\b`)},
+		{"converts backslashes", pathset.Options{Backslash: true}, []string{`.\a\b`, "a/b"}, set.Of("a/b")},
+		{"empty path", pathset.Options{}, []string{""}, set.Of(".")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := pathset.New(tc.opts)
+			s.Add(tc.v...)
+			got := set.Collect(s.All())
+			if !got.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+			if s.Size() != tc.want.Size() {
+				t.Errorf("got %v, wanted %v", s.Size(), tc.want.Size())
+			}
+		})
+	}
+}
+
+func TestSet_Contains(t *testing.T) {
+	cases := []struct {
+		name string
+		opts pathset.Options
+		v    string
+		want bool
+	}{
+		{"normalized path", pathset.Options{}, "./src/main.go", true},
+		{"different case", pathset.Options{}, "SRC/main.go", false},
+		{"different case insensitive", pathset.Options{CaseInsensitive: true}, "SRC/main.go", true},
+		{"backslashes", pathset.Options{Backslash: true}, `src\main.go`, true},
+		{"missing", pathset.Options{}, "src", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := pathset.New(tc.opts)
+			s.Add("src/main.go")
+			if got := s.Contains(tc.v); got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+	t.Run("zero set", func(t *testing.T) {
+		var s pathset.Set
+		if s.Contains("a") {
+			t.Errorf("zero set should not contain anything")
+		}
+	})
+}
+
+func TestSet_Delete(t *testing.T) {
+	s := pathset.New(pathset.Options{CaseInsensitive: true})
+	s.Add("a/b", "c")
+	if got := s.Delete("./A/B", "d"); got != 1 {
+		t.Errorf("got %v, wanted 1", got)
+	}
+	want := set.Of("c")
+	if got := set.Collect(s.All()); !got.Equal(want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestSet_Matching(t *testing.T) {
+	s := pathset.Of("main.go", "src/a.go", "src/a_test.go", "src/x/b.go", "src/x/y/c.go", "docs/readme.md", "/etc/hosts")
+	cases := []struct {
+		pattern string
+		want    set.Set[string]
+	}{
+		{"*.go", set.Of("main.go")},
+		{"src/*.go", set.Of("src/a.go", "src/a_test.go")},
+		{"./src/*_test.go", set.Of("src/a_test.go")},
+		{"src/**/*.go", set.Of("src/a.go", "src/a_test.go", "src/x/b.go", "src/x/y/c.go")},
+		{"**/*.go", set.Of("main.go", "src/a.go", "src/a_test.go", "src/x/b.go", "src/x/y/c.go")},
+		{"src/**", set.Of("src/a.go", "src/a_test.go", "src/x/b.go", "src/x/y/c.go")},
+		{"src/x/**/c.go", set.Of("src/x/y/c.go")},
+		{"src/?.go", set.Of("src/a.go")},
+		{"/etc/*", set.Of("/etc/hosts")},
+		{"*.txt", set.Of[string]()},
+		{"src/**/z", set.Of[string]()},
+	}
+	for _, tc := range cases {
+		t.Run(tc.pattern, func(t *testing.T) {
+			got, err := s.Matching(tc.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+	t.Run("case insensitive", func(t *testing.T) {
+		s := pathset.New(pathset.Options{CaseInsensitive: true})
+		s.Add("Src/Main.go")
+		got, err := s.Matching("src/*.GO")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := set.Of("Src/Main.go")
+		if !got.Equal(want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+	t.Run("should return error for malformed pattern", func(t *testing.T) {
+		_, err := s.Matching("src/[a")
+		if err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+	})
+}
+
+func TestSet_UnderDir(t *testing.T) {
+	s := pathset.Of("src", "src/a.go", "src/x/b.go", "srcx/c.go", "../d.go", "/etc/hosts")
+	cases := []struct {
+		dir  string
+		want set.Set[string]
+	}{
+		{"src", set.Of("src", "src/a.go", "src/x/b.go")},
+		{"./src/", set.Of("src", "src/a.go", "src/x/b.go")},
+		{"src/x", set.Of("src/x/b.go")},
+		{".", set.Of("src", "src/a.go", "src/x/b.go", "srcx/c.go")},
+		{"/", set.Of("/etc/hosts")},
+		{"..", set.Of("../d.go")},
+		{"docs", set.Of[string]()},
+	}
+	for _, tc := range cases {
+		t.Run(tc.dir, func(t *testing.T) {
+			got := s.UnderDir(tc.dir)
+			if !got.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSet_String(t *testing.T) {
+	s := pathset.Of("b", "./a")
+	if got, want := s.String(), "{a b}"; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
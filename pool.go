@@ -0,0 +1,38 @@
+package set
+
+import "sync"
+
+// A Pool is a pool of reusable sets, which reduces allocations and GC pressure
+// when many temporary sets are created, e.g. for deduplicating values while processing requests.
+//
+// Sets returned to the pool are cleared, but keep their allocated memory,
+// so that a set taken from the pool can often grow without allocating.
+// Like [sync.Pool] the pool may drop sets at any time, e.g. during garbage collection.
+//
+// The zero value of a Pool is an empty pool ready to use.
+// Pool is safe for concurrent use, but the sets taken from it are not.
+type Pool[E comparable] struct {
+	p sync.Pool
+}
+
+// Get returns an empty set from pool p or a new empty set if the pool has none.
+func (p *Pool[E]) Get() Set[E] {
+	if m, ok := p.p.Get().(map[E]struct{}); ok {
+		return Set[E]{m: m}
+	}
+	return Set[E]{m: make(map[E]struct{})}
+}
+
+// Put clears set s and adds it to pool p for reuse.
+// Zero sets are ignored.
+//
+// The set s and all copies of it must not be used after calling Put.
+func (p *Pool[E]) Put(s Set[E]) {
+	if s.m == nil {
+		return
+	}
+	w := beginWrite(s.m, "Pool.Put")
+	clear(s.m)
+	endWrite(w) // before the map can be reused by another goroutine
+	p.p.Put(s.m)
+}
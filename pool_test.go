@@ -0,0 +1,48 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestPool(t *testing.T) {
+	t.Run("should return empty set from empty pool", func(t *testing.T) {
+		var p set.Pool[int]
+		s := p.Get()
+		if s.IsZero() || s.Size() != 0 {
+			t.Errorf("got %q, wanted empty set", s)
+		}
+	})
+	t.Run("should return cleared sets", func(t *testing.T) {
+		var p set.Pool[int]
+		for range 10 {
+			s := p.Get()
+			if s.Size() != 0 {
+				t.Fatalf("got %q, wanted empty set", s)
+			}
+			s.Add(1, 2, 3)
+			p.Put(s)
+		}
+	})
+	t.Run("should ignore zero sets", func(t *testing.T) {
+		var p set.Pool[int]
+		p.Put(set.Set[int]{})
+		s := p.Get()
+		if s.IsZero() {
+			t.Errorf("got zero set, wanted empty set")
+		}
+	})
+	t.Run("should not allocate when reusing sets", func(t *testing.T) {
+		var p set.Pool[int]
+		p.Put(set.Of(1, 2, 3))
+		n := testing.AllocsPerRun(100, func() {
+			s := p.Get()
+			s.Add(4, 5, 6)
+			p.Put(s)
+		})
+		if n >= 1 { // the pool may drop sets, e.g. with the race detector
+			t.Errorf("got %v allocations, wanted less than 1", n)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package set
+
+import "cmp"
+
+// A PrioritySet is a set of unique elements, where each element has a priority.
+// It combines a set with a priority queue and can be used e.g. as deduplicated work queue.
+//
+// Elements are kept in a binary heap, so that adding, deleting and popping elements
+// and updating priorities take logarithmic time and looking up elements takes constant time.
+// Elements with the same priority are popped in undefined order.
+//
+// The zero value of a PrioritySet is an empty set ready to use.
+// PrioritySet is not safe for concurrent use.
+type PrioritySet[E comparable, P cmp.Ordered] struct {
+	heap  []priorityItem[E, P]
+	index map[E]int // position of elements in the heap
+}
+
+type priorityItem[E comparable, P cmp.Ordered] struct {
+	v    E
+	prio P
+}
+
+// Add adds element v with priority prio to set s and reports whether it was added.
+// Elements which are already in the set are not added and keep their priority.
+func (s *PrioritySet[E, P]) Add(v E, prio P) bool {
+	if _, ok := s.index[v]; ok {
+		return false
+	}
+	if s.index == nil {
+		s.index = make(map[E]int)
+	}
+	s.heap = append(s.heap, priorityItem[E, P]{v, prio})
+	s.index[v] = len(s.heap) - 1
+	s.up(len(s.heap) - 1)
+	return true
+}
+
+// Contains reports whether element v is in set s.
+func (s *PrioritySet[E, P]) Contains(v E) bool {
+	_, ok := s.index[v]
+	return ok
+}
+
+// Delete removes element v from set s and reports whether it was found.
+func (s *PrioritySet[E, P]) Delete(v E) bool {
+	i, ok := s.index[v]
+	if !ok {
+		return false
+	}
+	s.remove(i)
+	return true
+}
+
+// PeekHighest returns the element with the highest priority of set s and its priority
+// without removing it and reports whether the set was not empty.
+func (s *PrioritySet[E, P]) PeekHighest() (E, P, bool) {
+	if len(s.heap) == 0 {
+		var v E
+		var p P
+		return v, p, false
+	}
+	x := s.heap[0]
+	return x.v, x.prio, true
+}
+
+// PopHighest removes and returns the element with the highest priority of set s and its priority
+// and reports whether the set was not empty.
+func (s *PrioritySet[E, P]) PopHighest() (E, P, bool) {
+	v, p, ok := s.PeekHighest()
+	if ok {
+		s.remove(0)
+	}
+	return v, p, ok
+}
+
+// Priority returns the priority of element v and reports whether v is in set s.
+func (s *PrioritySet[E, P]) Priority(v E) (P, bool) {
+	i, ok := s.index[v]
+	if !ok {
+		var p P
+		return p, false
+	}
+	return s.heap[i].prio, true
+}
+
+// Size returns the number of elements in set s. An empty set returns 0.
+func (s *PrioritySet[E, P]) Size() int {
+	return len(s.heap)
+}
+
+// UpdatePriority changes the priority of element v to prio and reports whether v was found.
+func (s *PrioritySet[E, P]) UpdatePriority(v E, prio P) bool {
+	i, ok := s.index[v]
+	if !ok {
+		return false
+	}
+	s.heap[i].prio = prio
+	s.fix(i)
+	return true
+}
+
+// remove removes the element at position i of the heap.
+func (s *PrioritySet[E, P]) remove(i int) {
+	last := len(s.heap) - 1
+	delete(s.index, s.heap[i].v)
+	if i != last {
+		s.heap[i] = s.heap[last]
+		s.index[s.heap[i].v] = i
+	}
+	s.heap[last] = priorityItem[E, P]{} // allow garbage collection of the element
+	s.heap = s.heap[:last]
+	if i != last {
+		s.fix(i)
+	}
+}
+
+// fix restores the heap order after the priority of the element at position i has changed.
+func (s *PrioritySet[E, P]) fix(i int) {
+	if !s.down(i) {
+		s.up(i)
+	}
+}
+
+func (s *PrioritySet[E, P]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !s.higher(i, parent) {
+			break
+		}
+		s.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the element at position i down and reports whether it was moved.
+func (s *PrioritySet[E, P]) down(i int) bool {
+	start := i
+	n := len(s.heap)
+	for {
+		c := 2*i + 1
+		if c >= n {
+			break
+		}
+		if r := c + 1; r < n && s.higher(r, c) {
+			c = r
+		}
+		if !s.higher(c, i) {
+			break
+		}
+		s.swap(i, c)
+		i = c
+	}
+	return i > start
+}
+
+func (s *PrioritySet[E, P]) higher(i, j int) bool {
+	return cmp.Less(s.heap[j].prio, s.heap[i].prio)
+}
+
+func (s *PrioritySet[E, P]) swap(i, j int) {
+	s.heap[i], s.heap[j] = s.heap[j], s.heap[i]
+	s.index[s.heap[i].v] = i
+	s.index[s.heap[j].v] = j
+}
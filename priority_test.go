@@ -0,0 +1,140 @@
+package set_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestPrioritySet(t *testing.T) {
+	t.Run("should pop elements by priority", func(t *testing.T) {
+		var s set.PrioritySet[string, int]
+		s.Add("low", 1)
+		s.Add("high", 10)
+		s.Add("mid", 5)
+		for _, want := range []string{"high", "mid", "low"} {
+			got, _, ok := s.PopHighest()
+			if !ok || got != want {
+				t.Errorf("got %q, %v, wanted %q", got, ok, want)
+			}
+		}
+		if _, _, ok := s.PopHighest(); ok {
+			t.Errorf("wanted empty set")
+		}
+	})
+	t.Run("should enforce uniqueness", func(t *testing.T) {
+		var s set.PrioritySet[string, int]
+		if !s.Add("a", 1) {
+			t.Errorf("wanted element to be added")
+		}
+		if s.Add("a", 5) {
+			t.Errorf("wanted duplicate to not be added")
+		}
+		if got := s.Size(); got != 1 {
+			t.Errorf("got %v, wanted 1", got)
+		}
+		if got, _ := s.Priority("a"); got != 1 {
+			t.Errorf("got %v, wanted 1", got)
+		}
+	})
+	t.Run("can update priorities", func(t *testing.T) {
+		var s set.PrioritySet[string, int]
+		s.Add("a", 1)
+		s.Add("b", 2)
+		s.Add("c", 3)
+		if !s.UpdatePriority("a", 4) {
+			t.Errorf("wanted element to be found")
+		}
+		if got, _, _ := s.PeekHighest(); got != "a" {
+			t.Errorf("got %q, wanted %q", got, "a")
+		}
+		s.UpdatePriority("a", 0)
+		if got, p, _ := s.PeekHighest(); got != "c" || p != 3 {
+			t.Errorf("got %q with %v, wanted %q with 3", got, p, "c")
+		}
+		if s.UpdatePriority("x", 1) {
+			t.Errorf("wanted element to not be found")
+		}
+	})
+	t.Run("can delete elements", func(t *testing.T) {
+		var s set.PrioritySet[string, int]
+		s.Add("a", 1)
+		s.Add("b", 2)
+		if !s.Delete("b") {
+			t.Errorf("wanted element to be found")
+		}
+		if s.Delete("b") {
+			t.Errorf("wanted element to not be found")
+		}
+		if s.Contains("b") || !s.Contains("a") {
+			t.Errorf("got wrong elements after delete")
+		}
+		if _, ok := s.Priority("b"); ok {
+			t.Errorf("wanted no priority for deleted element")
+		}
+	})
+	t.Run("should report empty sets", func(t *testing.T) {
+		var s set.PrioritySet[string, float64]
+		if _, _, ok := s.PeekHighest(); ok {
+			t.Errorf("wanted empty set")
+		}
+		if s.Contains("a") || s.Delete("a") || s.Size() != 0 {
+			t.Errorf("wanted empty set")
+		}
+	})
+	t.Run("should behave like a sorted queue with random operations", func(t *testing.T) {
+		r := rand.New(rand.NewPCG(1, 2))
+		var s set.PrioritySet[int, int]
+		model := make(map[int]int) // element -> priority
+		for range 5000 {
+			v := r.IntN(100)
+			switch r.IntN(4) {
+			case 0:
+				p := r.IntN(1000)
+				_, exists := model[v]
+				if s.Add(v, p) == exists {
+					t.Fatalf("Add(%d) reported wrong result", v)
+				}
+				if !exists {
+					model[v] = p
+				}
+			case 1:
+				_, exists := model[v]
+				if s.Delete(v) != exists {
+					t.Fatalf("Delete(%d) reported wrong result", v)
+				}
+				delete(model, v)
+			case 2:
+				p := r.IntN(1000)
+				_, exists := model[v]
+				if s.UpdatePriority(v, p) != exists {
+					t.Fatalf("UpdatePriority(%d) reported wrong result", v)
+				}
+				if exists {
+					model[v] = p
+				}
+			case 3:
+				got, p, ok := s.PopHighest()
+				if ok != (len(model) > 0) {
+					t.Fatalf("PopHighest reported wrong result")
+				}
+				if !ok {
+					continue
+				}
+				for _, q := range model {
+					if q > p {
+						t.Fatalf("got priority %d, but %d is higher", p, q)
+					}
+				}
+				if model[got] != p {
+					t.Fatalf("got priority %d for %d, wanted %d", p, got, model[got])
+				}
+				delete(model, got)
+			}
+			if s.Size() != len(model) {
+				t.Fatalf("got size %d, wanted %d", s.Size(), len(model))
+			}
+		}
+	})
+}
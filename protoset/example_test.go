@@ -0,0 +1,23 @@
+package protoset_test
+
+import (
+	"fmt"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/ErikKalkoken/go-set/protoset"
+)
+
+func Example() {
+	// In a real application this is a field of a generated message, e.g. msg.Tags.
+	var tags []string
+
+	s := set.Of("b", "c", "a")
+	tags = protoset.ToRepeated(s)
+	fmt.Println(tags)
+
+	s2 := protoset.FromRepeated(tags)
+	fmt.Println(s2.Equal(s))
+	// Output:
+	// [a b c]
+	// true
+}
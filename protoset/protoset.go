@@ -0,0 +1,55 @@
+// Package protoset provides helpers for converting between sets and repeated protobuf fields.
+//
+// The helpers work with the types generated by protoc-gen-go,
+// where repeated scalar fields are slices and enums are named int32 types.
+// Therefore this package does not depend on the protobuf module.
+//
+// Sets are converted into sorted slices, so that marshaling the same set
+// always produces the same message bytes.
+package protoset
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+// FromRepeated returns a new set with the values of a repeated field.
+func FromRepeated[E comparable](v []E) set.Set[E] {
+	return set.Of(v...)
+}
+
+// FromEnum returns a new set with the values of a repeated enum field.
+// names is the name map generated for the enum type, e.g. Color_name.
+// It returns an error when v contains a value which is not defined in names.
+func FromEnum[E ~int32](v []E, names map[int32]string) (set.Set[E], error) {
+	for _, x := range v {
+		if _, ok := names[int32(x)]; !ok {
+			return set.Set[E]{}, fmt.Errorf("protoset: unknown enum value %d", x)
+		}
+	}
+	return set.Of(v...), nil
+}
+
+// ToRepeated returns the elements of set s as sorted slice for a repeated field.
+// Enums are sorted by their numeric value.
+// It returns nil for an empty set.
+func ToRepeated[E cmp.Ordered](s set.Set[E]) []E {
+	if s.Size() == 0 {
+		return nil
+	}
+	return slices.Sorted(s.All())
+}
+
+// ToRepeatedFunc returns the elements of set s as slice for a repeated field,
+// which is sorted with the function cmp.
+// This allows converting sets of elements, which are not ordered, e.g. bool.
+// It returns nil for an empty set.
+func ToRepeatedFunc[E comparable](s set.Set[E], cmp func(a, b E) int) []E {
+	if s.Size() == 0 {
+		return nil
+	}
+	return slices.SortedFunc(s.All(), cmp)
+}
@@ -0,0 +1,90 @@
+package protoset_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/ErikKalkoken/go-set/protoset"
+)
+
+// Color mimics an enum type generated by protoc-gen-go.
+type Color int32
+
+const (
+	Color_COLOR_UNSPECIFIED Color = 0
+	Color_COLOR_RED         Color = 1
+	Color_COLOR_BLUE        Color = 2
+)
+
+var Color_name = map[int32]string{
+	0: "COLOR_UNSPECIFIED",
+	1: "COLOR_RED",
+	2: "COLOR_BLUE",
+}
+
+func TestFromRepeated(t *testing.T) {
+	got := protoset.FromRepeated([]string{"b", "a", "b"})
+	want := set.Of("a", "b")
+	if !got.Equal(want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestFromEnum(t *testing.T) {
+	t.Run("can convert known values", func(t *testing.T) {
+		got, err := protoset.FromEnum([]Color{Color_COLOR_BLUE, Color_COLOR_RED, Color_COLOR_BLUE}, Color_name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := set.Of(Color_COLOR_RED, Color_COLOR_BLUE)
+		if !got.Equal(want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+	t.Run("should return error for unknown values", func(t *testing.T) {
+		_, err := protoset.FromEnum([]Color{Color_COLOR_RED, 7}, Color_name)
+		if err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+	})
+}
+
+func TestToRepeated(t *testing.T) {
+	cases := []struct {
+		name string
+		s    set.Set[Color]
+		want []Color
+	}{
+		{"sorted by value", set.Of(Color_COLOR_BLUE, Color_COLOR_UNSPECIFIED, Color_COLOR_RED), []Color{0, 1, 2}},
+		{"empty", set.Of[Color](), nil},
+		{"zero", set.Set[Color]{}, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := protoset.ToRepeated(tc.s)
+			if !slices.Equal(got, tc.want) || (got == nil) != (tc.want == nil) {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToRepeatedFunc(t *testing.T) {
+	cmpBool := func(a, b bool) int {
+		if a == b {
+			return 0
+		}
+		if !a {
+			return -1
+		}
+		return 1
+	}
+	got := protoset.ToRepeatedFunc(set.Of(true, false), cmpBool)
+	if want := []bool{false, true}; !slices.Equal(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+	if got := protoset.ToRepeatedFunc(set.Of[bool](), cmpBool); got != nil {
+		t.Errorf("got %v, wanted nil", got)
+	}
+}
@@ -0,0 +1,96 @@
+package set
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// JSONSchema returns the JSON Schema of the JSON encoding of set s,
+// which is an array of unique items or null for zero sets,
+// e.g. {"type": ["array", "null"], "uniqueItems": true, "items": {"type": "string"}} for a set of strings.
+//
+// The schema of the items is derived from the element type:
+// booleans, numbers and strings are described by their JSON type
+// and types implementing encoding.TextMarshaler as strings,
+// including methods with pointer receivers.
+// Times are described as strings with the date-time format.
+// Items of all other types are described with an empty schema, which allows any value.
+//
+// The schema can be used to document set fields in API specifications.
+// Schema generators do not pick up this method by themselves, because they expect their own schema types.
+// Instead the map can be converted into such a type by encoding it as JSON,
+// and registered as custom schema for the set type,
+// e.g. with the Mapper function of a reflector of github.com/invopop/jsonschema:
+//
+//	r := &jsonschema.Reflector{
+//		Mapper: func(t reflect.Type) *jsonschema.Schema {
+//			if t != reflect.TypeFor[set.Set[string]]() {
+//				return nil
+//			}
+//			b, _ := json.Marshal(set.Set[string]{}.JSONSchema())
+//			s := new(jsonschema.Schema)
+//			if err := json.Unmarshal(b, s); err != nil {
+//				return nil
+//			}
+//			return s
+//		},
+//	}
+func (s Set[E]) JSONSchema() map[string]any {
+	return jsonSchema[E]([]string{"array", "null"})
+}
+
+// JSONSchema returns the JSON Schema of the JSON encoding of set s,
+// which is an array of unique items and never null.
+// The schema of the items is described for [Set.JSONSchema].
+func (s NonNull[E]) JSONSchema() map[string]any {
+	return jsonSchema[E]("array")
+}
+
+// JSONSchema returns the JSON Schema of the JSON values accepted by set s,
+// which are either described by the schema of [Set.JSONSchema] or by the schema of its items,
+// e.g. {"oneOf": [{"type": ["array", "null"], ...}, {"type": "string"}]} for a set of strings.
+func (s Lenient[E]) JSONSchema() map[string]any {
+	return map[string]any{
+		"oneOf": []any{
+			jsonSchema[E]([]string{"array", "null"}),
+			jsonSchemaItems(reflect.TypeFor[E]()),
+		},
+	}
+}
+
+func jsonSchema[E comparable](typ any) map[string]any {
+	return map[string]any{
+		"type":        typ,
+		"uniqueItems": true,
+		"items":       jsonSchemaItems(reflect.TypeFor[E]()),
+	}
+}
+
+// jsonSchemaItems returns the JSON Schema for values of type t.
+func jsonSchemaItems(t reflect.Type) map[string]any {
+	// elements are encoded as pointers, so methods with pointer receivers are used as well
+	p := reflect.PointerTo(t)
+	switch {
+	case t == reflect.TypeFor[time.Time]():
+		return map[string]any{"type": "string", "format": "date-time"}
+	case p.Implements(reflect.TypeFor[json.Marshaler]()):
+		return map[string]any{}
+	case p.Implements(reflect.TypeFor[encoding.TextMarshaler]()):
+		return map[string]any{"type": "string"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	}
+	return map[string]any{}
+}
@@ -0,0 +1,61 @@
+package set_test
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+type jsonSchemer interface {
+	JSONSchema() map[string]any
+}
+
+func TestSet_JSONSchema(t *testing.T) {
+	cases := []struct {
+		name string
+		s    jsonSchemer
+		want string
+	}{
+		{"bool", set.Set[bool]{}, `{"items":{"type":"boolean"},"type":["array","null"],"uniqueItems":true}`},
+		{"int", set.Set[int]{}, `{"items":{"type":"integer"},"type":["array","null"],"uniqueItems":true}`},
+		{"uint", set.Set[uint8]{}, `{"items":{"minimum":0,"type":"integer"},"type":["array","null"],"uniqueItems":true}`},
+		{"float", set.Set[float64]{}, `{"items":{"type":"number"},"type":["array","null"],"uniqueItems":true}`},
+		{"string", set.Set[string]{}, `{"items":{"type":"string"},"type":["array","null"],"uniqueItems":true}`},
+		{"text marshaler", set.Set[netip.Addr]{}, `{"items":{"type":"string"},"type":["array","null"],"uniqueItems":true}`},
+		{"time", set.Set[time.Time]{}, `{"items":{"format":"date-time","type":"string"},"type":["array","null"],"uniqueItems":true}`},
+		{"json marshaler", set.Set[json.Number]{}, `{"items":{"type":"string"},"type":["array","null"],"uniqueItems":true}`},
+		{"custom json marshaler", set.Set[jsonMarshaler]{}, `{"items":{},"type":["array","null"],"uniqueItems":true}`},
+		{"pointer text marshaler", set.Set[pointerLevel]{}, `{"items":{"type":"string"},"type":["array","null"],"uniqueItems":true}`},
+		{"struct", set.Set[point]{}, `{"items":{},"type":["array","null"],"uniqueItems":true}`},
+		{"non null", set.NonNull[string]{}, `{"items":{"type":"string"},"type":"array","uniqueItems":true}`},
+		{"lenient", set.Lenient[string]{}, `{"oneOf":[{"items":{"type":"string"},"type":["array","null"],"uniqueItems":true},{"type":"string"}]}`},
+		{"strict", set.Strict[string]{}, `{"items":{"type":"string"},"type":["array","null"],"uniqueItems":true}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := json.Marshal(tc.s.JSONSchema())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(b); got != tc.want {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+}
+
+type jsonMarshaler struct{}
+
+func (jsonMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte("{}"), nil
+}
+
+// pointerLevel is an integer type, which implements encoding.TextMarshaler with a pointer receiver.
+type pointerLevel int
+
+func (x *pointerLevel) MarshalText() ([]byte, error) {
+	return []byte("lvl"), nil
+}
@@ -0,0 +1,20 @@
+package scopes_test
+
+import (
+	"fmt"
+
+	"github.com/ErikKalkoken/go-set/scopes"
+)
+
+func Example() {
+	granted := scopes.Parse("admin:* user:read")
+	fmt.Println(granted.Allows("admin:read"))
+	fmt.Println(granted.Allows("user:read"))
+	fmt.Println(granted.Allows("user:write"))
+	fmt.Println(granted.Contains("admin:read"))
+	// Output:
+	// true
+	// true
+	// false
+	// false
+}
@@ -0,0 +1,158 @@
+// Package scopes provides a set of permission scopes, where granted scopes can imply other scopes.
+//
+// A typical use case is checking OAuth scopes, where a granted scope like "admin:*"
+// also allows the more specific scopes "admin:read" and "admin:write".
+package scopes
+
+import (
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+// Wildcard is the default implication function of a [Set].
+// It reports whether scope granted implies scope required
+// when granted is "*" or ends with ":*" and required starts with the part before the "*",
+// e.g. "admin:*" implies "admin:read" and "admin:users:write", but not "admin".
+func Wildcard(granted, required string) bool {
+	p, ok := wildcardPrefix(granted)
+	return ok && strings.HasPrefix(required, p)
+}
+
+// A Set is a set of granted scopes.
+//
+// Checking whether a set allows a scope honors the implication between scopes,
+// while checking whether a set contains a scope only reports exact matches.
+//
+// The zero value of a Set is an empty set ready to use, which uses [Wildcard] for implication.
+// Set is not safe for concurrent use.
+type Set struct {
+	scopes set.Set[string]
+	// prefixes contains the prefixes of wildcard scopes, e.g. "admin:" for "admin:*"
+	prefixes set.Set[string]
+	imply    func(granted, required string) bool
+}
+
+// New returns a new set of the granted scopes v, which uses [Wildcard] for implication.
+// Wildcards are evaluated by prefix lookups and are therefore fast for sets of any size.
+func New(v ...string) *Set {
+	s := &Set{}
+	s.Add(v...)
+	return s
+}
+
+// NewFunc returns a new set of the granted scopes v, which uses imply for implication.
+// imply reports whether a granted scope implies a required scope.
+// Evaluating implications requires calling imply with each granted scope.
+func NewFunc(imply func(granted, required string) bool, v ...string) *Set {
+	s := &Set{imply: imply}
+	s.Add(v...)
+	return s
+}
+
+// Parse returns a new set of the granted scopes in s, which are separated by spaces,
+// e.g. "read:user repo:*". It uses [Wildcard] for implication.
+func Parse(s string) *Set {
+	return New(strings.Fields(s)...)
+}
+
+// Add adds the granted scopes v to set s.
+func (s *Set) Add(v ...string) {
+	for _, x := range v {
+		s.scopes.Add(x)
+		if p, ok := wildcardPrefix(x); ok {
+			s.prefixes.Add(p)
+		}
+	}
+}
+
+// All returns on iterator over all granted scopes of set s.
+//
+// Note that the order of the scopes is undefined.
+func (s *Set) All() iter.Seq[string] {
+	return s.scopes.All()
+}
+
+// Allows reports whether scope v is granted by set s, either directly or by implication.
+func (s *Set) Allows(v string) bool {
+	if s.scopes.Contains(v) {
+		return true
+	}
+	if s.imply != nil {
+		return s.scopes.ContainsFunc(func(g string) bool {
+			return s.imply(g, v)
+		})
+	}
+	if s.prefixes.Contains("") {
+		return true
+	}
+	for i := range len(v) {
+		if v[i] == ':' && s.prefixes.Contains(v[:i+1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsAll reports whether all scopes v are allowed by set s.
+func (s *Set) AllowsAll(v ...string) bool {
+	for _, x := range v {
+		if !s.Allows(x) {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowsAny reports whether any of the scopes v is allowed by set s.
+func (s *Set) AllowsAny(v ...string) bool {
+	for _, x := range v {
+		if s.Allows(x) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether scope v has been granted exactly, ignoring implications.
+func (s *Set) Contains(v string) bool {
+	return s.scopes.Contains(v)
+}
+
+// Delete removes the granted scopes v from set s.
+// It returns the number of deleted scopes.
+func (s *Set) Delete(v ...string) int {
+	n := s.scopes.Delete(v...)
+	for _, x := range v {
+		if p, ok := wildcardPrefix(x); ok {
+			s.prefixes.Delete(p)
+		}
+	}
+	return n
+}
+
+// Size returns the number of granted scopes in set s.
+func (s *Set) Size() int {
+	return s.scopes.Size()
+}
+
+// String returns the granted scopes sorted and separated by spaces,
+// which is the format used by OAuth.
+func (s *Set) String() string {
+	return strings.Join(slices.Sorted(s.scopes.All()), " ")
+}
+
+// wildcardPrefix returns the prefix of a wildcard scope
+// and reports whether v is a wildcard scope.
+func wildcardPrefix(v string) (string, bool) {
+	if v == "*" {
+		return "", true
+	}
+	p, ok := strings.CutSuffix(v, "*")
+	if !ok || !strings.HasSuffix(p, ":") {
+		return "", false
+	}
+	return p, true
+}
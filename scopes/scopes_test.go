@@ -0,0 +1,151 @@
+package scopes_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/ErikKalkoken/go-set/scopes"
+)
+
+func TestWildcard(t *testing.T) {
+	cases := []struct {
+		granted  string
+		required string
+		want     bool
+	}{
+		{"*", "admin:read", true},
+		{"admin:*", "admin:read", true},
+		{"admin:*", "admin:users:write", true},
+		{"admin:*", "admin", false},
+		{"admin:*", "administrator:read", false},
+		{"admin:users:*", "admin:read", false},
+		{"admin*", "admin:read", false},
+		{"admin:read", "admin:read", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.granted+" "+tc.required, func(t *testing.T) {
+			if got := scopes.Wildcard(tc.granted, tc.required); got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSet_Allows(t *testing.T) {
+	cases := []struct {
+		name    string
+		granted []string
+		v       string
+		want    bool
+	}{
+		{"exact", []string{"repo:read"}, "repo:read", true},
+		{"not granted", []string{"repo:read"}, "repo:write", false},
+		{"wildcard", []string{"repo:*"}, "repo:write", true},
+		{"nested wildcard", []string{"repo:*"}, "repo:hooks:write", true},
+		{"specific wildcard", []string{"repo:hooks:*"}, "repo:hooks:write", true},
+		{"specific wildcard does not imply parent", []string{"repo:hooks:*"}, "repo:write", false},
+		{"wildcard does not imply base", []string{"repo:*"}, "repo", false},
+		{"global wildcard", []string{"*"}, "anything", true},
+		{"empty", []string{}, "repo:read", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := scopes.New(tc.granted...)
+			if got := s.Allows(tc.v); got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+			// fast path must agree with the implication function
+			f := scopes.NewFunc(scopes.Wildcard, tc.granted...)
+			if got := f.Allows(tc.v); got != tc.want {
+				t.Errorf("func: got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+	t.Run("can use custom implication", func(t *testing.T) {
+		// write implies read
+		imply := func(granted, required string) bool {
+			p, ok := strings.CutSuffix(granted, ":write")
+			return ok && required == p+":read"
+		}
+		s := scopes.NewFunc(imply, "repo:write")
+		if !s.Allows("repo:read") {
+			t.Errorf("got false, wanted true")
+		}
+		if s.Allows("user:read") {
+			t.Errorf("got true, wanted false")
+		}
+	})
+	t.Run("zero set", func(t *testing.T) {
+		var s scopes.Set
+		if s.Allows("a") {
+			t.Errorf("got true, wanted false")
+		}
+		s.Add("a:*")
+		if !s.Allows("a:b") {
+			t.Errorf("got false, wanted true")
+		}
+	})
+}
+
+func TestSet_AllowsAllAny(t *testing.T) {
+	s := scopes.New("repo:*", "user:read")
+	cases := []struct {
+		name    string
+		v       []string
+		wantAll bool
+		wantAny bool
+	}{
+		{"all allowed", []string{"repo:read", "user:read"}, true, true},
+		{"some allowed", []string{"repo:read", "user:write"}, false, true},
+		{"none allowed", []string{"org:read", "user:write"}, false, false},
+		{"no scopes", []string{}, true, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := s.AllowsAll(tc.v...); got != tc.wantAll {
+				t.Errorf("all: got %v, wanted %v", got, tc.wantAll)
+			}
+			if got := s.AllowsAny(tc.v...); got != tc.wantAny {
+				t.Errorf("any: got %v, wanted %v", got, tc.wantAny)
+			}
+		})
+	}
+}
+
+func TestSet_Contains(t *testing.T) {
+	s := scopes.New("repo:*")
+	if !s.Contains("repo:*") {
+		t.Errorf("got false, wanted true")
+	}
+	if s.Contains("repo:read") {
+		t.Errorf("got true, wanted false")
+	}
+}
+
+func TestSet_Delete(t *testing.T) {
+	s := scopes.New("repo:*", "*", "user:read")
+	if got := s.Delete("repo:*", "*", "org:read"); got != 2 {
+		t.Errorf("got %v, wanted 2", got)
+	}
+	if s.Allows("repo:read") || s.Allows("org:read") {
+		t.Errorf("wildcards were not deleted")
+	}
+	want := set.Of("user:read")
+	if got := set.Collect(s.All()); !got.Equal(want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	if s.Size() != 1 {
+		t.Errorf("got %v, wanted 1", s.Size())
+	}
+}
+
+func TestParse(t *testing.T) {
+	s := scopes.Parse(" user:read  repo:* ")
+	if got, want := s.String(), "repo:* user:read"; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	if !s.Allows("repo:write") {
+		t.Errorf("got false, wanted true")
+	}
+}
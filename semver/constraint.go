@@ -0,0 +1,177 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A Constraint is a set of conditions, which versions can satisfy.
+//
+// Constraints are written as comparisons like ">=1.2.0" or "<2.0.0".
+// Multiple comparisons separated by spaces or commas must all be satisfied
+// and alternatives are separated by "||", e.g. ">=1.2.0, <2.0.0 || >=3.0.0".
+//
+// The following operators are supported:
+//
+//   - = or no operator: equal precedence
+//   - !=: different precedence
+//   - >, >=, <, <=: higher or lower precedence
+//   - ~1.2.3: patch updates, i.e. >=1.2.3, <1.3.0-0
+//   - ^1.2.3: updates which do not change the left-most non-zero number, i.e. >=1.2.3, <2.0.0-0
+//
+// Versions in constraints can be partial, e.g. ">=1.2" or "~1",
+// in which case the missing numbers are treated as 0.
+// The tilde operator with a partial version allows updates of the missing numbers,
+// e.g. "~1" is the same as ">=1.0.0, <2.0.0-0".
+type Constraint struct {
+	alternatives [][]comparison
+	raw          string
+}
+
+type operator int
+
+const (
+	opEqual operator = iota
+	opNotEqual
+	opGreater
+	opGreaterEqual
+	opLess
+	opLessEqual
+)
+
+type comparison struct {
+	op operator
+	v  Version
+}
+
+func (c comparison) check(v Version) bool {
+	r := comparePrecedence(v, c.v)
+	switch c.op {
+	case opNotEqual:
+		return r != 0
+	case opGreater:
+		return r > 0
+	case opGreaterEqual:
+		return r >= 0
+	case opLess:
+		return r < 0
+	case opLessEqual:
+		return r <= 0
+	}
+	return r == 0
+}
+
+// ParseConstraint parses s as a constraint.
+func ParseConstraint(s string) (Constraint, error) {
+	c := Constraint{raw: s}
+	for _, alt := range strings.Split(s, "||") {
+		var comparisons []comparison
+		for _, term := range strings.FieldsFunc(alt, func(r rune) bool {
+			return r == ' ' || r == ','
+		}) {
+			x, err := parseTerm(term)
+			if err != nil {
+				return Constraint{}, fmt.Errorf("semver: invalid constraint %q: %w", s, err)
+			}
+			comparisons = append(comparisons, x...)
+		}
+		if len(comparisons) == 0 {
+			return Constraint{}, fmt.Errorf("semver: invalid constraint %q: empty alternative", s)
+		}
+		c.alternatives = append(c.alternatives, comparisons)
+	}
+	return c, nil
+}
+
+// MustParseConstraint is like [ParseConstraint] but panics if s can not be parsed.
+func MustParseConstraint(s string) Constraint {
+	c, err := ParseConstraint(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Check reports whether version v satisfies constraint c.
+func (c Constraint) Check(v Version) bool {
+	for _, alt := range c.alternatives {
+		ok := true
+		for _, x := range alt {
+			if !x.check(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the constraint as it was parsed.
+func (c Constraint) String() string {
+	return c.raw
+}
+
+func parseTerm(term string) ([]comparison, error) {
+	var op string
+	for _, o := range []string{">=", "<=", "!=", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(term, o) {
+			op = o
+			break
+		}
+	}
+	v, n, err := parsePartial(term[len(op):])
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "~":
+		upper := Version{Major: v.Major + 1, Prerelease: "0"}
+		if n > 1 {
+			upper = Version{Major: v.Major, Minor: v.Minor + 1, Prerelease: "0"}
+		}
+		return []comparison{{opGreaterEqual, v}, {opLess, upper}}, nil
+	case "^":
+		var upper Version
+		switch {
+		case v.Major > 0 || n == 1:
+			upper = Version{Major: v.Major + 1, Prerelease: "0"}
+		case v.Minor > 0 || n == 2:
+			upper = Version{Minor: v.Minor + 1, Prerelease: "0"}
+		default:
+			upper = Version{Patch: v.Patch + 1, Prerelease: "0"}
+		}
+		return []comparison{{opGreaterEqual, v}, {opLess, upper}}, nil
+	}
+	ops := map[string]operator{
+		"":   opEqual,
+		"=":  opEqual,
+		"!=": opNotEqual,
+		">":  opGreater,
+		">=": opGreaterEqual,
+		"<":  opLess,
+		"<=": opLessEqual,
+	}
+	return []comparison{{ops[op], v}}, nil
+}
+
+// parsePartial parses a version where minor and patch can be omitted
+// and returns it together with the number of given numbers.
+func parsePartial(s string) (Version, int, error) {
+	s = strings.TrimPrefix(s, "v")
+	core, rest := s, ""
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		core, rest = s[:i], s[i:]
+	}
+	n := strings.Count(core, ".") + 1
+	if n < 3 {
+		core += strings.Repeat(".0", 3-n)
+	}
+	v, err := Parse(core + rest)
+	if err != nil {
+		return Version{}, 0, err
+	}
+	return v, n, nil
+}
@@ -0,0 +1,92 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/ErikKalkoken/go-set/semver"
+)
+
+func TestConstraint_Check(t *testing.T) {
+	cases := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3", "1.2.3+build", true},
+		{"1.2.3", "1.2.4", false},
+		{"=1.2.3", "1.2.3", true},
+		{"!=1.2.3", "1.2.3", false},
+		{"!=1.2.3", "1.2.4", true},
+		{">1.2.3", "1.2.4", true},
+		{">1.2.3", "1.2.3", false},
+		{">=1.2.3", "1.2.3", true},
+		{">=1.2.3", "1.2.3-rc.1", false},
+		{"<1.2.3", "1.2.3-rc.1", true},
+		{"<1.2.3", "1.2.3", false},
+		{"<=1.2.3", "1.2.3", true},
+		{"<=1.2.3", "1.2.4", false},
+		{">=1.2", "1.2.0", true},
+		{">=v1", "1.0.0", true},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.2.2", false},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2", "1.2.0", true},
+		{"~1", "1.9.0", true},
+		{"~1", "2.0.0", false},
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "1.2.2", false},
+		{"^1.2.3", "2.0.0", false},
+		{"^1.2.3", "2.0.0-alpha", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^0.0", "0.0.9", true},
+		{"^0.0", "0.1.0", false},
+		{"^0", "0.9.0", true},
+		{"^0", "1.0.0", false},
+		{">=1.0.0 <2.0.0", "1.5.0", true},
+		{">=1.0.0, <2.0.0", "2.0.0", false},
+		{"<1.0.0 || >=2.0.0", "0.5.0", true},
+		{"<1.0.0 || >=2.0.0", "1.5.0", false},
+		{"<1.0.0 || >=2.0.0", "2.5.0", true},
+		{">=1.0.0-rc.1", "1.0.0-rc.2", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.constraint+" "+tc.version, func(t *testing.T) {
+			c, err := semver.ParseConstraint(tc.constraint)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := c.Check(semver.MustParse(tc.version))
+			if got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseConstraint(t *testing.T) {
+	t.Run("should return error for invalid constraints", func(t *testing.T) {
+		for _, s := range []string{"", ">=", "1.2.3 ||", ">=1.x", "=>1.2.3", "1.2.3.4"} {
+			if _, err := semver.ParseConstraint(s); err == nil {
+				t.Errorf("%q: got %q, wanted error", s, err)
+			}
+		}
+	})
+	t.Run("should return original string", func(t *testing.T) {
+		s := ">=1.2.0, <2.0.0"
+		if got := semver.MustParseConstraint(s).String(); got != s {
+			t.Errorf("got %q, wanted %q", got, s)
+		}
+	})
+	t.Run("should panic on invalid constraint", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic when it was expected to")
+			}
+		}()
+		semver.MustParseConstraint(">=")
+	})
+}
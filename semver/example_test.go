@@ -0,0 +1,27 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/ErikKalkoken/go-set/semver"
+)
+
+func Example() {
+	s, err := semver.ParseAll("1.2.0", "1.10.0", "1.9.3", "2.0.0-rc.1")
+	if err != nil {
+		panic(err)
+	}
+	for v := range s.Sorted() {
+		fmt.Println(v)
+	}
+	latest, _ := s.Latest()
+	fmt.Println("Latest:", latest)
+	fmt.Println("Matching:", s.Matching(semver.MustParseConstraint("~1.9")))
+	// Output:
+	// 1.2.0
+	// 1.9.3
+	// 1.10.0
+	// 2.0.0-rc.1
+	// Latest: 2.0.0-rc.1
+	// Matching: {1.9.3}
+}
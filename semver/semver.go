@@ -0,0 +1,244 @@
+// Package semver provides a set of semantic versions with constraint matching.
+//
+// Versions follow the [Semantic Versioning 2.0.0] specification
+// and are ordered by precedence, not lexicographically.
+//
+// [Semantic Versioning 2.0.0]: https://semver.org/spec/v2.0.0.html
+package semver
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+// A Version is a semantic version.
+//
+// Versions are comparable and can therefore be used as elements of a [set.Set].
+// Note that two versions which only differ in their build metadata
+// are different elements, even though they have the same precedence.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string // dot separated pre-release identifiers without the leading hyphen
+	Build      string // dot separated build identifiers without the leading plus sign
+}
+
+// Parse parses s as a semantic version. A leading "v" is accepted.
+func Parse(s string) (Version, error) {
+	var v Version
+	r := strings.TrimPrefix(s, "v")
+	r, build, hasBuild := strings.Cut(r, "+")
+	r, pre, hasPre := strings.Cut(r, "-")
+	parts := strings.Split(r, ".")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("semver: invalid version %q: must have major, minor and patch", s)
+	}
+	var nums [3]uint64
+	for i, p := range parts {
+		n, err := parseNumber(p)
+		if err != nil {
+			return v, fmt.Errorf("semver: invalid version %q: %w", s, err)
+		}
+		nums[i] = n
+	}
+	if hasPre {
+		if err := validateIdentifiers(pre, true); err != nil {
+			return v, fmt.Errorf("semver: invalid version %q: pre-release: %w", s, err)
+		}
+	}
+	if hasBuild {
+		if err := validateIdentifiers(build, false); err != nil {
+			return v, fmt.Errorf("semver: invalid version %q: build: %w", s, err)
+		}
+	}
+	v = Version{Major: nums[0], Minor: nums[1], Patch: nums[2], Prerelease: pre, Build: build}
+	return v, nil
+}
+
+// MustParse is like [Parse] but panics if s can not be parsed.
+func MustParse(s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns the canonical string representation of version v without a leading "v".
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (v *Version) UnmarshalText(b []byte) error {
+	w, err := Parse(string(b))
+	if err != nil {
+		return err
+	}
+	*v = w
+	return nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether a has a lower, equal or higher precedence than b.
+// Build metadata is ignored when determining precedence.
+// Versions with equal precedence are ordered by their build metadata to give a total order.
+func Compare(a, b Version) int {
+	if c := comparePrecedence(a, b); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Build, b.Build)
+}
+
+func comparePrecedence(a, b Version) int {
+	if c := cmp.Compare(a.Major, b.Major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Minor, b.Minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Patch, b.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(a.Prerelease, b.Prerelease)
+}
+
+func comparePrerelease(a, b string) int {
+	if a == b {
+		return 0
+	}
+	// a version without pre-release has a higher precedence
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+	x := strings.Split(a, ".")
+	y := strings.Split(b, ".")
+	for i := range min(len(x), len(y)) {
+		n1, err1 := strconv.ParseUint(x[i], 10, 64)
+		n2, err2 := strconv.ParseUint(y[i], 10, 64)
+		var c int
+		switch {
+		case err1 == nil && err2 == nil:
+			c = cmp.Compare(n1, n2)
+		case err1 == nil:
+			c = -1 // numeric identifiers have lower precedence
+		case err2 == nil:
+			c = 1
+		default:
+			c = strings.Compare(x[i], y[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(x), len(y))
+}
+
+// A VersionSet is a set of versions, which can be queried with constraints
+// and iterated by precedence.
+//
+// The zero value of a VersionSet is an empty set ready to use.
+type VersionSet struct {
+	set.Set[Version]
+}
+
+// Of returns a new version set of the versions v.
+func Of(v ...Version) VersionSet {
+	return VersionSet{set.Of(v...)}
+}
+
+// ParseAll returns a new version set from the parsed versions v.
+// It returns an error when any of the versions can not be parsed.
+func ParseAll(v ...string) (VersionSet, error) {
+	var s VersionSet
+	for _, x := range v {
+		w, err := Parse(x)
+		if err != nil {
+			return VersionSet{}, err
+		}
+		s.Add(w)
+	}
+	return s, nil
+}
+
+// Latest returns the version with the highest precedence in s
+// and reports whether s is not empty.
+func (s VersionSet) Latest() (Version, bool) {
+	if s.Size() == 0 {
+		return Version{}, false
+	}
+	return set.MaxFunc(s.Set, Compare), true
+}
+
+// Matching returns a new set with the versions of s, which satisfy constraint c.
+func (s VersionSet) Matching(c Constraint) set.Set[Version] {
+	var r set.Set[Version]
+	for v := range s.All() {
+		if c.Check(v) {
+			r.Add(v)
+		}
+	}
+	return r
+}
+
+// Sorted returns an iterator over all versions of s in ascending order of precedence.
+func (s VersionSet) Sorted() iter.Seq[Version] {
+	return slices.Values(slices.SortedFunc(s.All(), Compare))
+}
+
+func parseNumber(s string) (uint64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty number")
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return 0, fmt.Errorf("number %q has leading zero", s)
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return n, nil
+}
+
+func validateIdentifiers(s string, noLeadingZeros bool) error {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return fmt.Errorf("empty identifier")
+		}
+		numeric := true
+		for _, r := range id {
+			switch {
+			case r >= '0' && r <= '9':
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '-':
+				numeric = false
+			default:
+				return fmt.Errorf("invalid character %q in identifier %q", r, id)
+			}
+		}
+		if noLeadingZeros && numeric && len(id) > 1 && id[0] == '0' {
+			return fmt.Errorf("numeric identifier %q has leading zero", id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package semver_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/ErikKalkoken/go-set/semver"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in        string
+		want      semver.Version
+		wantError bool
+	}{
+		{"1.2.3", semver.Version{Major: 1, Minor: 2, Patch: 3}, false},
+		{"v1.2.3", semver.Version{Major: 1, Minor: 2, Patch: 3}, false},
+		{"0.0.0", semver.Version{}, false},
+		{"1.2.3-alpha.1", semver.Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "alpha.1"}, false},
+		{"1.2.3+build.5", semver.Version{Major: 1, Minor: 2, Patch: 3, Build: "build.5"}, false},
+		{"1.2.3-rc-1+001", semver.Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc-1", Build: "001"}, false},
+		{"1.2", semver.Version{}, true},
+		{"1.2.3.4", semver.Version{}, true},
+		{"01.2.3", semver.Version{}, true},
+		{"1.x.3", semver.Version{}, true},
+		{"1..3", semver.Version{}, true},
+		{"1.2.3-", semver.Version{}, true},
+		{"1.2.3-01", semver.Version{}, true},
+		{"1.2.3-a..b", semver.Version{}, true},
+		{"1.2.3-a_b", semver.Version{}, true},
+		{"1.2.3+", semver.Version{}, true},
+		{"1.2.3+a$", semver.Version{}, true},
+		{"99999999999999999999.0.0", semver.Version{}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := semver.Parse(tc.in)
+			if tc.wantError {
+				if err == nil {
+					t.Errorf("got %q, wanted error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got %q, wanted no error", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	t.Run("can parse", func(t *testing.T) {
+		got := semver.MustParse("1.2.3")
+		if want := (semver.Version{Major: 1, Minor: 2, Patch: 3}); got != want {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+	t.Run("should panic on invalid version", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic when it was expected to")
+			}
+		}()
+		semver.MustParse("1.2")
+	})
+}
+
+func TestVersion_String(t *testing.T) {
+	for _, s := range []string{"1.2.3", "1.2.3-alpha.1", "1.2.3+build", "1.2.3-rc.1+build.2"} {
+		if got := semver.MustParse(s).String(); got != s {
+			t.Errorf("got %q, wanted %q", got, s)
+		}
+	}
+}
+
+func TestVersion_JSON(t *testing.T) {
+	s1 := semver.Of(semver.MustParse("1.2.3-rc.1"))
+	b, err := json.Marshal(s1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `["1.2.3-rc.1"]`; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	var s2 semver.VersionSet
+	if err := json.Unmarshal(b, &s2); err != nil {
+		t.Fatal(err)
+	}
+	if !s2.Equal(s1.Set) {
+		t.Errorf("got %q, wanted %q", s2, s1)
+	}
+	if err := json.Unmarshal([]byte(`["1.2"]`), &s2); err == nil {
+		t.Errorf("got %q, wanted error", err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// ordered by precedence according to the semver specification
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.0+build.1",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			got := semver.Compare(semver.MustParse(a), semver.MustParse(b))
+			var want int
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got != want {
+				t.Errorf("%s vs %s: got %v, wanted %v", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	t.Run("can parse", func(t *testing.T) {
+		got, err := semver.ParseAll("1.0.0", "v1.0.0", "2.0.0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := set.Of(semver.MustParse("1.0.0"), semver.MustParse("2.0.0"))
+		if !got.Equal(want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+	t.Run("should return error on invalid version", func(t *testing.T) {
+		_, err := semver.ParseAll("1.0.0", "1.0")
+		if err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+	})
+}
+
+func TestVersionSet_Latest(t *testing.T) {
+	cases := []struct {
+		name   string
+		s      []string
+		want   string
+		wantOK bool
+	}{
+		{"multiple versions", []string{"1.10.0", "1.9.0", "1.2.0"}, "1.10.0", true},
+		{"pre-release", []string{"2.0.0-rc.1", "1.9.0"}, "2.0.0-rc.1", true},
+		{"release after pre-release", []string{"2.0.0-rc.1", "2.0.0"}, "2.0.0", true},
+		{"empty", []string{}, "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := semver.ParseAll(tc.s...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := s.Latest()
+			if ok != tc.wantOK {
+				t.Errorf("got %v, wanted %v", ok, tc.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if got.String() != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVersionSet_Sorted(t *testing.T) {
+	s, err := semver.ParseAll("1.10.0", "1.2.0", "1.9.0", "1.2.0-beta", "0.9.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for v := range s.Sorted() {
+		got = append(got, v.String())
+	}
+	want := []string{"0.9.0", "1.2.0-beta", "1.2.0", "1.9.0", "1.10.0"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestVersionSet_Matching(t *testing.T) {
+	s, err := semver.ParseAll("0.9.0", "1.0.0", "1.2.0", "1.2.5", "1.3.0", "2.0.0-rc.1", "2.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := s.Matching(semver.MustParseConstraint("^1.0.0"))
+	want, _ := semver.ParseAll("1.0.0", "1.2.0", "1.2.5", "1.3.0")
+	if !got.Equal(want.Set) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
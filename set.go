@@ -11,6 +11,38 @@
 //		   t.Errorf("got %q, wanted %q", got, want)
 //	  }
 //
+// # Floating point elements
+//
+// Sets of floats follow the equality of Go's map keys:
+// 0.0 and -0.0 are the same element and NaN is never equal to any element, including itself.
+// Each NaN added to a set is therefore a distinct element,
+// which can never be found with [Set.Contains] or removed with [Set.Delete].
+// Use [AddNonNaN] to reject NaN values when adding elements and [DeleteNaN] to remove them.
+// [EqualApprox] compares sets of floats with a tolerance.
+//
+// # Encoding custom element types
+//
+// All encodings of sets use the standard interfaces of the element types,
+// which may be implemented with value or pointer receivers:
+//
+//   - JSON encodings use the json.Marshaler or encoding.TextMarshaler interfaces
+//     as described for encoding/json.
+//   - Binary encodings, e.g. [Set.MarshalBinary], [FrozenSet] and the temporary files of [SpillSet],
+//     use the encoding.BinaryMarshaler interface and fall back to encoding.TextMarshaler
+//     for types, which can not be encoded directly.
+//
+// Decoding uses the unmarshaler interfaces corresponding to the marshalers.
+// Element types need to implement both sides for round-tripping, e.g. UnmarshalBinary for MarshalBinary.
+// Binary decoding returns an error wrapping [ErrUnsupportedType] if the unmarshaler is missing.
+//
+// # Detecting concurrent writes
+//
+// Sets are not safe for concurrent use. To help find concurrent writes to a set,
+// the package can be built with the setdebug build tag, e.g. go test -tags setdebug ./...
+// Writes to a set, which is already being written to by another goroutine,
+// will then panic with a message naming the method, e.g. "set.Add: concurrent write to set detected".
+// Detection is best-effort and only covers writes, but never reports false positives.
+//
 // # Zero sets
 //
 // The zero value of a Set or "zero set" is an empty set ready to use.
@@ -20,10 +52,10 @@ package set
 
 import (
 	"cmp"
-	"encoding/json"
 	"fmt"
 	"iter"
 	"maps"
+	"math/rand/v2"
 	"slices"
 	"strings"
 )
@@ -39,6 +71,13 @@ type Set[E comparable] struct {
 	_ nocmp
 }
 
+// New returns a new empty set with space for at least capacity elements.
+// Preallocating space avoids growing the set while adding elements,
+// when the number of elements is known in advance.
+func New[E comparable](capacity int) Set[E] {
+	return Set[E]{m: make(map[E]struct{}, capacity)}
+}
+
 // Of returns a new set of the elements v.
 // Providing no elements will return an empty and initialized set.
 func Of[E comparable](v ...E) Set[E] {
@@ -59,9 +98,11 @@ func (s *Set[E]) Add(v ...E) {
 	if s.m == nil {
 		s.m = make(map[E]struct{})
 	}
-	for _, w := range v {
-		s.m[w] = struct{}{}
-	}
+	guardWrite(s.m, "Add", func() {
+		for _, w := range v {
+			s.m[w] = struct{}{}
+		}
+	})
 }
 
 // AddSeq adds the values from seq to s.
@@ -73,7 +114,9 @@ func (s *Set[E]) AddSeq(seq iter.Seq[E]) {
 
 // Clear removes all elements from set s.
 func (s Set[E]) Clear() {
+	p := beginWrite(s.m, "Clear")
 	clear(s.m)
+	endWrite(p)
 }
 
 // Clone returns a new set, which contains a shallow copy of all elements of set s.
@@ -98,6 +141,16 @@ func (s Set[E]) ContainsAny(seq iter.Seq[E]) bool {
 	return false
 }
 
+// ContainsAnyOf reports whether any of the elements v are in s.
+func (s Set[E]) ContainsAnyOf(v ...E) bool {
+	for _, w := range v {
+		if _, ok := s.m[w]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 // ContainsAll reports whether all of the elements in seq are in s.
 func (s Set[E]) ContainsAll(seq iter.Seq[E]) bool {
 	for v := range seq {
@@ -108,6 +161,16 @@ func (s Set[E]) ContainsAll(seq iter.Seq[E]) bool {
 	return true
 }
 
+// ContainsAllOf reports whether all of the elements v are in s.
+func (s Set[E]) ContainsAllOf(v ...E) bool {
+	for _, w := range v {
+		if _, ok := s.m[w]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // ContainsFunc reports whether at least one element v of s satisfies f(v).
 func (s Set[E]) ContainsFunc(f func(E) bool) bool {
 	if f == nil || len(s.m) == 0 {
@@ -121,14 +184,30 @@ func (s Set[E]) ContainsFunc(f func(E) bool) bool {
 	return false
 }
 
+// CountContained returns the number of distinct elements in seq, which are in s.
+func (s Set[E]) CountContained(seq iter.Seq[E]) int {
+	if len(s.m) == 0 {
+		return 0
+	}
+	var found Set[E]
+	for v := range seq {
+		if _, ok := s.m[v]; ok {
+			found.Add(v)
+		}
+	}
+	return found.Size()
+}
+
 // Delete removes elements v from set s.
 // It returns the number of deleted elements.
 // Elements that are not found in the set are ignored.
 func (s Set[E]) Delete(v ...E) int {
 	ln := len(s.m)
-	for _, w := range v {
-		delete(s.m, w)
-	}
+	guardWrite(s.m, "Delete", func() {
+		for _, w := range v {
+			delete(s.m, w)
+		}
+	})
 	return ln - len(s.m)
 }
 
@@ -141,7 +220,9 @@ func (s Set[E]) DeleteFunc(del func(E) bool) int {
 	ln := len(s.m)
 	for v := range s.m {
 		if del(v) {
+			p := beginWrite(s.m, "DeleteFunc")
 			delete(s.m, v)
+			endWrite(p)
 		}
 	}
 	return ln - len(s.m)
@@ -155,7 +236,9 @@ func (s Set[E]) DeleteSeq(seq iter.Seq[E]) int {
 	for v := range seq {
 		_, ok := s.m[v]
 		if ok {
+			p := beginWrite(s.m, "DeleteSeq")
 			delete(s.m, v)
+			endWrite(p)
 			c++
 		}
 	}
@@ -181,6 +264,12 @@ func (s Set[E]) Equal(u Set[E]) bool {
 	return true
 }
 
+// IsEmpty reports whether set s has no elements.
+// In contrast to [Set.IsZero] this is true for both empty and zero sets.
+func (s Set[E]) IsEmpty() bool {
+	return len(s.m) == 0
+}
+
 // IsZero reports whether set s is a zero value.
 func (s Set[E]) IsZero() bool {
 	return s.m == nil
@@ -190,14 +279,7 @@ func (s Set[E]) IsZero() bool {
 // Sets are converted to JSON arrays.
 // Zero sets will be converted into JSON null.
 func (s Set[T]) MarshalJSON() ([]byte, error) {
-	if s.m == nil {
-		return json.Marshal(nil)
-	}
-	v := make([]T, 0)
-	for x := range s.All() {
-		v = append(v, x)
-	}
-	return json.Marshal(v)
+	return s.AppendText(nil)
 }
 
 // Pop tries to remove and return an arbitrary element from s
@@ -211,7 +293,9 @@ func (s Set[E]) Pop() (E, bool) {
 		v = k
 		break
 	}
+	p := beginWrite(s.m, "Pop")
 	delete(s.m, v)
+	endWrite(p)
 	return v, true
 }
 
@@ -233,21 +317,35 @@ func (s Set[E]) String() string {
 
 // UnmarshalJSON parses the JSON-encoded data b and replaces the current set.
 // JSON null values will be unmarshaled into a zero set.
+// The elements of an existing set are replaced in place,
+// so that copies of the set see the new elements.
+// The set is not changed when unmarshaling fails.
+//
+// All elements are unmarshaled before they are added to the set.
+// Use [DecodeJSON] for decoding large JSON arrays from a stream with less memory.
 func (s *Set[T]) UnmarshalJSON(b []byte) error {
-	var i []T
-	err := json.Unmarshal(b, &i)
+	v, err := unmarshalJSONElements[T](b)
 	if err != nil {
 		return err
 	}
-	if i == nil {
+	if v == nil {
 		s.m = nil
 		return nil
 	}
-	s.Clear()
-	s.Add(i...)
+	s.replace(v)
 	return nil
 }
 
+// replace replaces the elements of set s in place with the elements v.
+func (s *Set[E]) replace(v []E) {
+	if s.m == nil {
+		s.m = make(map[E]struct{}, len(v))
+	} else {
+		s.Clear()
+	}
+	s.Add(v...)
+}
+
 // Collect collects values from seq into a new set and returns it.
 // If seq is empty, the result is a zero set.
 func Collect[E comparable](seq iter.Seq[E]) Set[E] {
@@ -258,6 +356,21 @@ func Collect[E comparable](seq iter.Seq[E]) Set[E] {
 	return r
 }
 
+// Complement returns a new [Set] with the elements of universe, which are not in s.
+// This is the same as Difference(universe, s),
+// but can be faster when s is small compared to universe, e.g. for a universe created with [Range].
+func Complement[E comparable](s, universe Set[E]) Set[E] {
+	if len(s.m) < len(universe.m)/2 {
+		// copying the universe is faster than rebuilding it when most elements remain
+		r := universe.Clone()
+		for v := range s.m {
+			delete(r.m, v)
+		}
+		return r
+	}
+	return Difference(universe, s)
+}
+
 // Difference constructs a new [Set] containing the elements of s
 // that are not present in the union of others.
 // When no others are provided it returns a set with the elements of s.
@@ -280,6 +393,26 @@ func Difference[E comparable](s Set[E], others ...Set[E]) Set[E] {
 	return r
 }
 
+// DistinctBy returns an iterator over the elements of seq,
+// which yields only the first element for each key as returned by key.
+// This allows deduplicating elements, which are not comparable, e.g. structs by their ID.
+// The keys are tracked in a set, which is only kept while iterating.
+func DistinctBy[S any, K comparable](seq iter.Seq[S], key func(S) K) iter.Seq[S] {
+	return func(yield func(S) bool) {
+		var seen Set[K]
+		for v := range seq {
+			k := key(v)
+			if seen.Contains(k) {
+				continue
+			}
+			seen.Add(k)
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
 // Intersection returns a new [Set] with elements common to all sets.
 // When less then two sets are provided it returns an empty set.
 func Intersection[E comparable](sets ...Set[E]) Set[E] {
@@ -322,75 +455,211 @@ type comparableAndOrderable interface {
 }
 
 // Max returns the maximal value in s. It panics if s is empty.
+// Use [TryMax] for sets which can be empty.
 func Max[E comparableAndOrderable](s Set[E]) E {
-	if s.Size() < 1 {
+	m, ok := TryMax(s)
+	if !ok {
 		panic("set.Max: empty set")
 	}
-	var m E
-	for x := range s.All() {
-		m = x
-		break
-	}
-	for x := range s.All() {
-		m = max(m, x)
-	}
 	return m
 }
 
+// MaxBy returns the element of s with the maximal key, which is computed with the function key.
+// The key is computed once for each element.
+// It panics if s is empty.
+// If there is more than one element with the maximal key, MaxBy returns the first one.
+func MaxBy[E comparable, K cmp.Ordered](s Set[E], key func(E) K) E {
+	return extremeBy(s, key, 1, "set.MaxBy: empty set")
+}
+
 // MaxFunc returns the maximal value in s, using cmp to compare elements.
 // It panics if s is empty.
 // If there is more than one maximal element according to the cmp function, MaxFunc returns the first one.
+// Use [TryMaxFunc] for sets which can be empty.
 func MaxFunc[E comparable](s Set[E], cmp func(a, b E) int) E {
-	if s.Size() < 1 {
+	m, ok := TryMaxFunc(s, cmp)
+	if !ok {
 		panic("set.MaxFunc: empty set")
 	}
+	return m
+}
+
+// Min returns the minimal value in s. It panics if s is empty.
+// Use [TryMin] for sets which can be empty.
+func Min[E comparableAndOrderable](s Set[E]) E {
+	m, ok := TryMin(s)
+	if !ok {
+		panic("set.Min: empty set")
+	}
+	return m
+}
+
+// MinBy returns the element of s with the minimal key, which is computed with the function key.
+// The key is computed once for each element.
+// It panics if s is empty.
+// If there is more than one element with the minimal key, MinBy returns the first one.
+func MinBy[E comparable, K cmp.Ordered](s Set[E], key func(E) K) E {
+	return extremeBy(s, key, -1, "set.MinBy: empty set")
+}
+
+// MinFunc returns the minimal value in s, using cmp to compare elements.
+// It panics if s is empty.
+// If there is more than one minimal element according to the cmp function, MinFunc returns the first one.
+// Use [TryMinFunc] for sets which can be empty.
+func MinFunc[E comparable](s Set[E], cmp func(a, b E) int) E {
+	m, ok := TryMinFunc(s, cmp)
+	if !ok {
+		panic("set.MinFunc: empty set")
+	}
+	return m
+}
+
+// Range returns a new set with the integers from start up to but not including end,
+// which are incremented by step, e.g. Range(0, 10, 3) returns the set {0 3 6 9}.
+// A negative step counts down from start to end.
+// If the range is empty, the result is a zero set.
+// It panics if step is 0.
+func Range[E integer](start, end, step E) Set[E] {
+	if step == 0 {
+		panic("set.Range: step must not be 0")
+	}
+	var r Set[E]
+	var d, n uint64 // distance to end and size of step
+	if step > 0 {
+		if start >= end {
+			return r
+		}
+		d, n = uint64(end)-uint64(start), uint64(step)
+	} else {
+		if start <= end {
+			return r
+		}
+		d, n = uint64(start)-uint64(end), -uint64(step)
+	}
+	r.m = make(map[E]struct{}, (d-1)/n+1)
+	for v := start; ; v += step {
+		r.m[v] = struct{}{}
+		if d <= n {
+			break
+		}
+		d -= n
+	}
+	return r
+}
+
+// Split partitions set s into k random disjoint sets,
+// whose sizes differ by at most one element, e.g. for cross-validation or staged rollouts.
+// Elements are assigned using the random number generator r
+// or the global random number generator when r is nil.
+// It panics if k is not positive.
+//
+// Note that the result is random even for a seeded r, because the order of elements in a set is undefined.
+func Split[E comparable](s Set[E], k int, r *rand.Rand) []Set[E] {
+	if k < 1 {
+		panic("set.Split: k must be positive")
+	}
+	v := slices.Collect(s.All())
+	shuffle := rand.Shuffle
+	if r != nil {
+		shuffle = r.Shuffle
+	}
+	shuffle(len(v), func(i, j int) {
+		v[i], v[j] = v[j], v[i]
+	})
+	parts := make([]Set[E], k)
+	for i := range parts {
+		parts[i].m = make(map[E]struct{}, (len(v)+k-1-i)/k)
+	}
+	for i, x := range v {
+		parts[i%k].m[x] = struct{}{}
+	}
+	return parts
+}
+
+// TaggedUnion returns the union of sets together with the provenance of each element.
+// The result maps each element of the union to the ascending indices of the sets,
+// which contain the element, e.g. for reporting merges and conflicts.
+// Computing it takes linear time in the total number of elements of all sets.
+func TaggedUnion[E comparable](sets ...Set[E]) map[E][]int {
+	r := make(map[E][]int)
+	for i, s := range sets {
+		for v := range s.m {
+			r[v] = append(r[v], i)
+		}
+	}
+	return r
+}
+
+// TryMax returns the maximal value in s and reports whether s is not empty.
+func TryMax[E comparableAndOrderable](s Set[E]) (E, bool) {
 	var m E
-	for x := range s.All() {
+	if len(s.m) == 0 {
+		return m, false
+	}
+	for x := range s.m {
 		m = x
 		break
 	}
-	for x := range s.All() {
+	for x := range s.m {
+		m = max(m, x)
+	}
+	return m, true
+}
+
+// TryMaxFunc returns the maximal value in s, using cmp to compare elements,
+// and reports whether s is not empty.
+// If there is more than one maximal element according to the cmp function, TryMaxFunc returns the first one.
+func TryMaxFunc[E comparable](s Set[E], cmp func(a, b E) int) (E, bool) {
+	var m E
+	if len(s.m) == 0 {
+		return m, false
+	}
+	for x := range s.m {
+		m = x
+		break
+	}
+	for x := range s.m {
 		if cmp(x, m) > 0 {
 			m = x
 		}
 	}
-	return m
+	return m, true
 }
 
-// Min returns the minimal value in s. It panics if s is empty.
-func Min[E comparableAndOrderable](s Set[E]) E {
-	if s.Size() < 1 {
-		panic("set.Min: empty set")
-	}
+// TryMin returns the minimal value in s and reports whether s is not empty.
+func TryMin[E comparableAndOrderable](s Set[E]) (E, bool) {
 	var m E
-	for x := range s.All() {
+	if len(s.m) == 0 {
+		return m, false
+	}
+	for x := range s.m {
 		m = x
 		break
 	}
-	for x := range s.All() {
+	for x := range s.m {
 		m = min(m, x)
 	}
-	return m
+	return m, true
 }
 
-// MinFunc returns the minimal value in s, using cmp to compare elements.
-// It panics if s is empty.
-// If there is more than one minimal element according to the cmp function, MinFunc returns the first one.
-func MinFunc[E comparable](s Set[E], cmp func(a, b E) int) E {
-	if s.Size() < 1 {
-		panic("set.MinFunc: empty set")
-	}
+// TryMinFunc returns the minimal value in s, using cmp to compare elements,
+// and reports whether s is not empty.
+// If there is more than one minimal element according to the cmp function, TryMinFunc returns the first one.
+func TryMinFunc[E comparable](s Set[E], cmp func(a, b E) int) (E, bool) {
 	var m E
-	for x := range s.All() {
+	if len(s.m) == 0 {
+		return m, false
+	}
+	for x := range s.m {
 		m = x
 		break
 	}
-	for x := range s.All() {
+	for x := range s.m {
 		if cmp(x, m) < 0 {
 			m = x
 		}
 	}
-	return m
+	return m, true
 }
 
 // Union returns a new [Set] with has the combined elements of all provided sets.
@@ -423,5 +692,24 @@ func Union[E comparable](sets ...Set[E]) Set[E] {
 	return r
 }
 
+// extremeBy returns the element of s with the maximal key for sign 1
+// or the minimal key for sign -1 and panics with msg if s is empty.
+func extremeBy[E comparable, K cmp.Ordered](s Set[E], key func(E) K, sign int, msg string) E {
+	if len(s.m) == 0 {
+		panic(msg)
+	}
+	var m E
+	var mk K
+	first := true
+	for x := range s.m {
+		k := key(x)
+		if first || cmp.Compare(k, mk)*sign > 0 {
+			m, mk = x, k
+			first = false
+		}
+	}
+	return m
+}
+
 // nocmp is an uncomparable struct. Embed this inside another struct to make it uncomparable.
 type nocmp [0]func()
@@ -4,6 +4,9 @@ import (
 	"cmp"
 	"encoding/json"
 	"iter"
+	"maps"
+	"math/rand/v2"
+	"slices"
 	"testing"
 
 	"github.com/ErikKalkoken/go-set"
@@ -154,6 +157,31 @@ func TestSet_ContainsAny(t *testing.T) {
 	}
 }
 
+func TestSet_ContainsAnyOf(t *testing.T) {
+	cases := []struct {
+		name string
+		s    set.Set[int]
+		v    []int
+		want bool
+	}{
+		{"non-empty contains some elements", set.Of(1, 2), []int{2, 3}, true},
+		{"non-empty contains all elements", set.Of(1, 2), []int{1, 2}, true},
+		{"non-empty contains no elements", set.Of(1, 2), []int{3, 4}, false},
+		{"no elements", set.Of(1, 2), []int{}, false},
+		{"empty set with elements", set.Of[int](), []int{1}, false},
+		{"zero set with elements", set.Set[int]{}, []int{1}, false},
+		{"zero set with no elements", set.Set[int]{}, []int{}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.s.ContainsAnyOf(tc.v...)
+			if got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestSet_ContainsAll(t *testing.T) {
 	cases := []struct {
 		name string
@@ -180,6 +208,31 @@ func TestSet_ContainsAll(t *testing.T) {
 	}
 }
 
+func TestSet_ContainsAllOf(t *testing.T) {
+	cases := []struct {
+		name string
+		s    set.Set[int]
+		v    []int
+		want bool
+	}{
+		{"non-empty contains some elements", set.Of(1, 2), []int{2, 3}, false},
+		{"non-empty contains all elements", set.Of(1, 2), []int{1, 2}, true},
+		{"non-empty contains no elements", set.Of(1, 2), []int{3, 4}, false},
+		{"no elements", set.Of(1, 2), []int{}, true},
+		{"empty set with elements", set.Of[int](), []int{1}, false},
+		{"zero set with elements", set.Set[int]{}, []int{1}, false},
+		{"zero set with no elements", set.Set[int]{}, []int{}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.s.ContainsAllOf(tc.v...)
+			if got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestSet_ContainsFunc(t *testing.T) {
 	f := func(i int) bool {
 		return i%2 == 0
@@ -207,6 +260,31 @@ func TestSet_ContainsFunc(t *testing.T) {
 	}
 }
 
+func TestSet_CountContained(t *testing.T) {
+	cases := []struct {
+		name string
+		s    set.Set[int]
+		seq  iter.Seq[int]
+		want int
+	}{
+		{"some elements", set.Of(1, 2, 3), slices.Values([]int{2, 3, 4}), 2},
+		{"all elements", set.Of(1, 2), slices.Values([]int{1, 2}), 2},
+		{"no elements", set.Of(1, 2), slices.Values([]int{3, 4}), 0},
+		{"duplicates in seq", set.Of(1, 2), slices.Values([]int{1, 1, 2, 1}), 2},
+		{"empty seq", set.Of(1, 2), slices.Values([]int{}), 0},
+		{"empty set", set.Of[int](), slices.Values([]int{1}), 0},
+		{"zero set", set.Set[int]{}, slices.Values([]int{1}), 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.s.CountContained(tc.seq)
+			if got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestSet_Equal(t *testing.T) {
 	cases := []struct {
 		name string
@@ -343,6 +421,27 @@ func TestSet_DeleteSeq(t *testing.T) {
 	}
 }
 
+func TestSet_IsEmpty(t *testing.T) {
+	cases := []struct {
+		name string
+		s    set.Set[int]
+		want bool
+	}{
+		{"non-empty 2 elements", set.Of(1, 2), false},
+		{"non-empty 1 element", set.Of(1), false},
+		{"empty", set.Of[int](), true},
+		{"zero", set.Set[int]{}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.s.IsEmpty()
+			if got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestSet_IsZero(t *testing.T) {
 	cases := []struct {
 		name string
@@ -397,6 +496,15 @@ func TestSet_MarshallJSON(t *testing.T) {
 			})
 		}
 	})
+	t.Run("should marshal sets of bytes as arrays", func(t *testing.T) {
+		got, err := json.Marshal(set.Of[byte](255))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "[255]"; string(got) != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
 }
 
 func TestSet_UnmarshallJSON(t *testing.T) {
@@ -412,6 +520,10 @@ func TestSet_UnmarshallJSON(t *testing.T) {
 			{"multiple elements", "[1,2]", set.Of(1, 2), false, false},
 			{"empty set", "[]", set.Of[int](), false, false},
 			{"zero set", "null", set.Set[int]{}, true, false},
+			{"trailing whitespace", "[1] \n", set.Of(1), false, false},
+			{"trailing array", "[1] [2]", set.Set[int]{}, false, true},
+			{"trailing bracket", "[1]]", set.Set[int]{}, false, true},
+			{"trailing data after null", "null x", set.Set[int]{}, false, true},
 		}
 		for _, tc := range cases {
 			t.Run(tc.name, func(t *testing.T) {
@@ -454,6 +566,16 @@ func TestSet_UnmarshallJSON(t *testing.T) {
 			t.Errorf("got %q, wanted %q", s2, s1)
 		}
 	})
+	t.Run("should replace elements in place for copies of the set", func(t *testing.T) {
+		a := set.Of(1, 2)
+		b := a
+		if err := json.Unmarshal([]byte("[3]"), &a); err != nil {
+			t.Fatal(err)
+		}
+		if want := set.Of(3); !a.Equal(want) || !b.Equal(want) {
+			t.Errorf("got %q and %q, wanted %q", a, b, want)
+		}
+	})
 	t.Run("should return error when unmarshalling fails", func(t *testing.T) {
 		var s2 set.Set[int]
 		err := s2.UnmarshalJSON([]byte(" "))
@@ -461,6 +583,16 @@ func TestSet_UnmarshallJSON(t *testing.T) {
 			t.Errorf("got %q, wanted error", err)
 		}
 	})
+	t.Run("should not change set when unmarshalling fails", func(t *testing.T) {
+		s2 := set.Of(4)
+		err := json.Unmarshal([]byte(`[1,"a"]`), &s2)
+		if err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+		if want := set.Of(4); !s2.Equal(want) {
+			t.Errorf("got %q, wanted %q", s2, want)
+		}
+	})
 }
 
 func TestSet_Pop(t *testing.T) {
@@ -544,6 +676,35 @@ func TestSet_String(t *testing.T) {
 	}
 }
 
+func TestNew(t *testing.T) {
+	t.Run("should return empty set", func(t *testing.T) {
+		s := set.New[int](10)
+		if s.Size() != 0 || s.IsZero() {
+			t.Errorf("got %v, wanted empty and initialized set", s)
+		}
+		s.Add(1)
+		if want := set.Of(1); !s.Equal(want) {
+			t.Errorf("got %v, wanted %v", s, want)
+		}
+	})
+	t.Run("should allocate less when adding elements", func(t *testing.T) {
+		fill := func(s set.Set[int]) {
+			for i := range 1000 {
+				s.Add(i)
+			}
+		}
+		got := testing.AllocsPerRun(10, func() {
+			fill(set.New[int](1000))
+		})
+		want := testing.AllocsPerRun(10, func() {
+			fill(set.Of[int]())
+		})
+		if got >= want {
+			t.Errorf("got %v allocations, wanted less than %v", got, want)
+		}
+	})
+}
+
 func TestOf(t *testing.T) {
 	cases := []struct {
 		name string
@@ -589,6 +750,39 @@ func TestCollect(t *testing.T) {
 	}
 }
 
+func TestComplement(t *testing.T) {
+	cases := []struct {
+		name     string
+		s        set.Set[int]
+		universe set.Set[int]
+		want     set.Set[int]
+	}{
+		{"small set", set.Of(2), set.Of(1, 2, 3, 4), set.Of(1, 3, 4)},
+		{"large set", set.Of(1, 2, 3), set.Of(1, 2, 3, 4), set.Of(4)},
+		{"set with other elements", set.Of(2, 5), set.Of(1, 2, 3, 4), set.Of(1, 3, 4)},
+		{"set equals universe", set.Of(1, 2), set.Of(1, 2), set.Of[int]()},
+		{"empty set", set.Of[int](), set.Of(1, 2), set.Of(1, 2)},
+		{"zero set", set.Set[int]{}, set.Of(1, 2), set.Of(1, 2)},
+		{"empty universe", set.Of(1), set.Of[int](), set.Of[int]()},
+		{"zero universe", set.Of(1), set.Set[int]{}, set.Of[int]()},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := set.Complement(tc.s, tc.universe)
+			if !got.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+	t.Run("should not change universe", func(t *testing.T) {
+		universe := set.Range(0, 10, 1)
+		set.Complement(set.Of(1), universe)
+		if got := universe.Size(); got != 10 {
+			t.Errorf("got %v, wanted 10", got)
+		}
+	})
+}
+
 func TestDifference(t *testing.T) {
 	cases := []struct {
 		name   string
@@ -615,6 +809,49 @@ func TestDifference(t *testing.T) {
 	}
 }
 
+func TestDistinctBy(t *testing.T) {
+	type user struct {
+		id   int
+		tags []string // not comparable
+	}
+	key := func(u user) int {
+		return u.id
+	}
+	t.Run("should yield first element per key", func(t *testing.T) {
+		users := []user{{1, []string{"a"}}, {2, nil}, {1, []string{"b"}}, {3, nil}, {2, nil}}
+		var got []int
+		var tags []string
+		for u := range set.DistinctBy(slices.Values(users), key) {
+			got = append(got, u.id)
+			tags = append(tags, u.tags...)
+		}
+		if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+		if want := []string{"a"}; !slices.Equal(tags, want) {
+			t.Errorf("got %v, wanted %v", tags, want)
+		}
+	})
+	t.Run("should start over for each iteration", func(t *testing.T) {
+		seq := set.DistinctBy(slices.Values([]user{{1, nil}, {1, nil}}), key)
+		for range 2 {
+			if got := len(slices.Collect(seq)); got != 1 {
+				t.Errorf("got %v, wanted 1", got)
+			}
+		}
+	})
+	t.Run("can stop iterating", func(t *testing.T) {
+		var n int
+		for range set.DistinctBy(slices.Values([]user{{1, nil}, {2, nil}}), key) {
+			n++
+			break
+		}
+		if n != 1 {
+			t.Errorf("got %v, wanted 1", n)
+		}
+	})
+}
+
 func TestIntersection(t *testing.T) {
 	cases := []struct {
 		name string
@@ -775,6 +1012,219 @@ func TestMinFunc(t *testing.T) {
 	}
 }
 
+func TestMinMaxBy(t *testing.T) {
+	type user struct {
+		name string
+		age  int
+	}
+	ann, bob, cat := user{"ann", 30}, user{"bob", 25}, user{"cat", 35}
+	t.Run("should return element with extreme key", func(t *testing.T) {
+		s := set.Of(ann, bob, cat)
+		var calls int
+		age := func(u user) int {
+			calls++
+			return u.age
+		}
+		if got := set.MaxBy(s, age); got != cat {
+			t.Errorf("got %v, wanted %v", got, cat)
+		}
+		if got := set.MinBy(s, age); got != bob {
+			t.Errorf("got %v, wanted %v", got, bob)
+		}
+		if calls != 6 {
+			t.Errorf("got %v calls, wanted key to be computed once per element", calls)
+		}
+	})
+	t.Run("can handle single elements", func(t *testing.T) {
+		s := set.Of(ann)
+		if got := set.MaxBy(s, func(u user) string { return u.name }); got != ann {
+			t.Errorf("got %v, wanted %v", got, ann)
+		}
+	})
+	t.Run("should panic for empty sets", func(t *testing.T) {
+		for _, fn := range []func(set.Set[user], func(user) int) user{set.MaxBy[user, int], set.MinBy[user, int]} {
+			func() {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Errorf("The code did not panic when it was expected to")
+					}
+				}()
+				fn(set.Set[user]{}, func(u user) int { return u.age })
+			}()
+		}
+	})
+}
+
+func TestTaggedUnion(t *testing.T) {
+	cases := []struct {
+		name string
+		sets []set.Set[int]
+		want map[int][]int
+	}{
+		{"overlapping", []set.Set[int]{set.Of(1, 2), set.Of(2, 3), set.Of(2)}, map[int][]int{1: {0}, 2: {0, 1, 2}, 3: {1}}},
+		{"disjoint", []set.Set[int]{set.Of(1), set.Of(2)}, map[int][]int{1: {0}, 2: {1}}},
+		{"with empty and zero", []set.Set[int]{set.Of[int](), {}, set.Of(1)}, map[int][]int{1: {2}}},
+		{"one set only", []set.Set[int]{set.Of(1, 2)}, map[int][]int{1: {0}, 2: {0}}},
+		{"no sets", []set.Set[int]{}, map[int][]int{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := set.TaggedUnion(tc.sets...)
+			if !maps.EqualFunc(got, tc.want, slices.Equal) {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTryMinMax(t *testing.T) {
+	cases := []struct {
+		name     string
+		s        set.Set[int]
+		min, max int
+		ok       bool
+	}{
+		{"several items", set.Of(2, 1, 3), 1, 3, true},
+		{"one item", set.Of(1), 1, 1, true},
+		{"empty", set.Of[int](), 0, 0, false},
+		{"zero", set.Set[int]{}, 0, 0, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checks := []struct {
+				name string
+				fn   func(set.Set[int]) (int, bool)
+				want int
+			}{
+				{"TryMax", set.TryMax[int], tc.max},
+				{"TryMaxFunc", func(s set.Set[int]) (int, bool) { return set.TryMaxFunc(s, cmp.Compare[int]) }, tc.max},
+				{"TryMin", set.TryMin[int], tc.min},
+				{"TryMinFunc", func(s set.Set[int]) (int, bool) { return set.TryMinFunc(s, cmp.Compare[int]) }, tc.min},
+			}
+			for _, c := range checks {
+				got, ok := c.fn(tc.s)
+				if got != c.want || ok != tc.ok {
+					t.Errorf("%s: got %v, %v, wanted %v, %v", c.name, got, ok, c.want, tc.ok)
+				}
+			}
+		})
+	}
+}
+
+func TestRange(t *testing.T) {
+	t.Run("can create ranges", func(t *testing.T) {
+		cases := []struct {
+			name             string
+			start, end, step int
+			want             set.Set[int]
+		}{
+			{"consecutive", 1, 4, 1, set.Of(1, 2, 3)},
+			{"stepped", 0, 10, 3, set.Of(0, 3, 6, 9)},
+			{"step reaches end", 0, 9, 3, set.Of(0, 3, 6)},
+			{"one element", 5, 6, 1, set.Of(5)},
+			{"step larger than range", 0, 5, 10, set.Of(0)},
+			{"negative step", 3, 0, -1, set.Of(3, 2, 1)},
+			{"negative stepped", 10, -1, -5, set.Of(10, 5, 0)},
+			{"negative values", -3, 0, 1, set.Of(-3, -2, -1)},
+			{"start equals end", 1, 1, 1, set.Set[int]{}},
+			{"start after end", 4, 1, 1, set.Set[int]{}},
+			{"start before end with negative step", 1, 4, -1, set.Set[int]{}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				got := set.Range(tc.start, tc.end, tc.step)
+				if !got.Equal(tc.want) {
+					t.Errorf("got %q, wanted %q", got, tc.want)
+				}
+			})
+		}
+	})
+	t.Run("should not overflow at the limits of the type", func(t *testing.T) {
+		got := set.Range[uint8](250, 255, 3)
+		if want := set.Of[uint8](250, 253); !got.Equal(want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+		got2 := set.Range[int8](-126, -128, -1)
+		if want := set.Of[int8](-126, -127); !got2.Equal(want) {
+			t.Errorf("got %q, wanted %q", got2, want)
+		}
+		got3 := set.Range[int8](-128, 127, 127)
+		if want := set.Of[int8](-128, -1, 126); !got3.Equal(want) {
+			t.Errorf("got %q, wanted %q", got3, want)
+		}
+		got4 := set.Range[int8](127, -128, -128)
+		if want := set.Of[int8](127, -1); !got4.Equal(want) {
+			t.Errorf("got %q, wanted %q", got4, want)
+		}
+	})
+	t.Run("should panic when step is 0", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic when it was expected to")
+			}
+		}()
+		set.Range(0, 10, 0)
+	})
+}
+
+func TestSplit(t *testing.T) {
+	t.Run("should partition set into random disjoint sets of near-equal size", func(t *testing.T) {
+		cases := []struct {
+			name      string
+			s         set.Set[int]
+			k         int
+			wantSizes []int
+		}{
+			{"even", set.Range(0, 9, 1), 3, []int{3, 3, 3}},
+			{"uneven", set.Range(0, 10, 1), 3, []int{4, 3, 3}},
+			{"one part", set.Range(0, 5, 1), 1, []int{5}},
+			{"more parts than elements", set.Of(1, 2), 4, []int{1, 1, 0, 0}},
+			{"empty set", set.Of[int](), 2, []int{0, 0}},
+			{"zero set", set.Set[int]{}, 2, []int{0, 0}},
+		}
+		r := rand.New(rand.NewPCG(1, 2))
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				got := set.Split(tc.s, tc.k, r)
+				var sizes []int
+				for _, p := range got {
+					sizes = append(sizes, p.Size())
+				}
+				if !slices.Equal(sizes, tc.wantSizes) {
+					t.Errorf("got sizes %v, wanted %v", sizes, tc.wantSizes)
+				}
+				if u := set.Union(got...); !u.Equal(tc.s) {
+					t.Errorf("got union %q, wanted %q", u, tc.s)
+				}
+			})
+		}
+	})
+	t.Run("should assign elements randomly", func(t *testing.T) {
+		// each element should be in the first part about half of the time
+		s := set.Range(0, 10, 1)
+		counts := make(map[int]int)
+		for range 1000 {
+			parts := set.Split(s, 2, nil)
+			for v := range parts[0].All() {
+				counts[v]++
+			}
+		}
+		for v := range s.All() {
+			if c := counts[v]; c < 400 || c > 600 {
+				t.Errorf("element %d was %d times in the first part, wanted about 500", v, c)
+			}
+		}
+	})
+	t.Run("should panic when k is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic when it was expected to")
+			}
+		}()
+		set.Split(set.Of(1), 0, nil)
+	})
+}
+
 func TestUnion(t *testing.T) {
 	cases := []struct {
 		name string
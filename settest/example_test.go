@@ -0,0 +1,17 @@
+package settest_test
+
+import (
+	"fmt"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/ErikKalkoken/go-set/settest"
+)
+
+func ExampleFormat() {
+	s := set.Of("charlie", "alpha", "bravo")
+	fmt.Print(settest.Format(s))
+	// Output:
+	// alpha
+	// bravo
+	// charlie
+}
@@ -0,0 +1,136 @@
+// Package settest provides helpers for testing code which computes sets.
+//
+// Sets have no defined order, which makes comparing them against expected output
+// from files difficult. This package writes sets in a canonical text format
+// with one element per line sorted by their string representation,
+// which can be stored as golden file and compared with [MatchGolden].
+//
+// Golden files are updated by running the tests with the environment variable
+// SETTEST_UPDATE set to a true value, e.g.:
+//
+//	SETTEST_UPDATE=1 go test ./...
+//
+// Golden files are also updated when the test package defines its own boolean -update flag
+// and the tests are run with it, e.g.:
+//
+//	go test ./path/to/pkg -update
+package settest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+// updateEnv is the environment variable, which enables updating golden files.
+const updateEnv = "SETTEST_UPDATE"
+
+// Format returns the canonical text representation of set s.
+// Each element is printed with [fmt.Sprint] on a separate line
+// and the lines are sorted, e.g. "a\nb\n" for the set {a b}.
+// Empty sets are formatted as empty string.
+//
+// Elements should print to a single line, or the result can not be parsed unambiguously.
+func Format[E comparable](s set.Set[E]) string {
+	lines := formatLines(s)
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// MatchGolden compares set s with the golden file at path
+// and reports an error to t with the missing and unexpected elements if they differ.
+// The golden file contains the set in the format produced by [Format].
+//
+// When updating golden files as described in the package documentation,
+// the golden file is written instead including any missing parent directories.
+func MatchGolden[E comparable](t testing.TB, s set.Set[E], path string) {
+	t.Helper()
+	if updating() {
+		if err := writeGolden(path, Format(s)); err != nil {
+			t.Fatalf("settest: update golden file: %s", err)
+		}
+		return
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("settest: read golden file: %s (run with %s=1 to create it)", err, updateEnv)
+		return
+	}
+	want := parseLines(b)
+	got := set.Of(formatLines(s)...)
+	missing := sortedLines(set.Difference(want, got))
+	unexpected := sortedLines(set.Difference(got, want))
+	if len(missing) == 0 && len(unexpected) == 0 {
+		return
+	}
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "set does not match golden file %s", path)
+	for _, v := range missing {
+		fmt.Fprintf(&msg, "\n- %s", v)
+	}
+	for _, v := range unexpected {
+		fmt.Fprintf(&msg, "\n+ %s", v)
+	}
+	t.Errorf("%s", msg.String())
+}
+
+func formatLines[E comparable](s set.Set[E]) []string {
+	var p []string
+	for v := range s.All() {
+		p = append(p, fmt.Sprint(v))
+	}
+	slices.Sort(p)
+	return p
+}
+
+// parseLines returns the non-empty lines of a golden file.
+func parseLines(b []byte) set.Set[string] {
+	var s set.Set[string]
+	for _, line := range bytes.Split(b, []byte("\n")) {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if len(line) > 0 {
+			s.Add(string(line))
+		}
+	}
+	return s
+}
+
+func sortedLines(s set.Set[string]) []string {
+	return slices.Sorted(s.All())
+}
+
+func writeGolden(path, data string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(data), 0o644)
+}
+
+// updating reports whether golden files should be updated.
+// The -update flag is looked up when called,
+// so that it only needs to be defined by test packages which want to use it.
+func updating() bool {
+	if v, err := strconv.ParseBool(os.Getenv(updateEnv)); err == nil && v {
+		return true
+	}
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+	g, ok := f.Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+	v, _ := g.Get().(bool)
+	return v
+}
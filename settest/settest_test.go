@@ -0,0 +1,140 @@
+package settest_test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/ErikKalkoken/go-set/settest"
+)
+
+var _ = flag.Bool("update", false, "update golden files")
+
+func TestFormat(t *testing.T) {
+	cases := []struct {
+		name string
+		s    set.Set[int]
+		want string
+	}{
+		{"multiple elements", set.Of(3, 1, 2), "1\n2\n3\n"},
+		{"one element", set.Of(1), "1\n"},
+		{"empty set", set.Of[int](), ""},
+		{"zero set", set.Set[int]{}, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := settest.Format(tc.s)
+			if got != tc.want {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMatchGolden(t *testing.T) {
+	t.Run("should pass when set matches golden file", func(t *testing.T) {
+		path := writeFile(t, "a\r\nb\n\nc")
+		f := &fakeTB{TB: t}
+		settest.MatchGolden(f, set.Of("c", "b", "a"), path)
+		if f.failed {
+			t.Errorf("got %q, wanted no failure", f.msg)
+		}
+	})
+	t.Run("should report missing and unexpected elements", func(t *testing.T) {
+		path := writeFile(t, "a\nb\n")
+		f := &fakeTB{TB: t}
+		settest.MatchGolden(f, set.Of("b", "c"), path)
+		if !f.failed || f.fatal {
+			t.Fatalf("got failed %v and fatal %v, wanted error", f.failed, f.fatal)
+		}
+		want := fmt.Sprintf("set does not match golden file %s\n- a\n+ c", path)
+		if f.msg != want {
+			t.Errorf("got %q, wanted %q", f.msg, want)
+		}
+	})
+	t.Run("should fail when golden file does not exist", func(t *testing.T) {
+		f := &fakeTB{TB: t}
+		settest.MatchGolden(f, set.Of(1), filepath.Join(t.TempDir(), "missing.golden"))
+		if !f.fatal {
+			t.Errorf("got %q, wanted fatal error", f.msg)
+		}
+	})
+	t.Run("should write golden file when updating", func(t *testing.T) {
+		setUpdate(t)
+		path := filepath.Join(t.TempDir(), "testdata", "ids.golden")
+		f := &fakeTB{TB: t}
+		settest.MatchGolden(f, set.Of(2, 1), path)
+		if f.failed {
+			t.Fatalf("got %q, wanted no failure", f.msg)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "1\n2\n"; string(b) != want {
+			t.Errorf("got %q, wanted %q", b, want)
+		}
+	})
+	t.Run("should write golden file when the -update flag of the test package is set", func(t *testing.T) {
+		if err := flag.Set("update", "true"); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			flag.Set("update", "false")
+		})
+		path := filepath.Join(t.TempDir(), "ids.golden")
+		f := &fakeTB{TB: t}
+		settest.MatchGolden(f, set.Of(1), path)
+		if f.failed {
+			t.Fatalf("got %q, wanted no failure", f.msg)
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("should fail when golden file can not be written", func(t *testing.T) {
+		setUpdate(t)
+		path := filepath.Join(writeFile(t, ""), "ids.golden") // parent is a file
+		f := &fakeTB{TB: t}
+		settest.MatchGolden(f, set.Of(1), path)
+		if !f.fatal {
+			t.Errorf("got %q, wanted fatal error", f.msg)
+		}
+	})
+}
+
+func setUpdate(t *testing.T) {
+	t.Setenv("SETTEST_UPDATE", "1")
+}
+
+func writeFile(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "set.golden")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// fakeTB records failures instead of reporting them to the test.
+type fakeTB struct {
+	testing.TB
+	failed bool
+	fatal  bool
+	msg    string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.failed = true
+	f.msg = fmt.Sprintf(format, args...)
+}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.Errorf(format, args...)
+	f.fatal = true
+}
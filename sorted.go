@@ -0,0 +1,183 @@
+package set
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// A SortedSet is an immutable set of ordered elements, which is backed by a sorted slice.
+//
+// Looking up elements takes logarithmic time with binary search.
+// Set operations merge the sorted slices and use galloping (exponential) search
+// to skip quickly over runs of elements, which makes intersecting a small with a large set very fast.
+// Compared to a [Set] a SortedSet needs much less memory and iterates in ascending order,
+// which makes it a good choice for sets which are built once and queried many times.
+//
+// Floating point NaN values are treated as equal to each other and ordered before all other values.
+//
+// The zero value of a SortedSet is an empty set ready to use.
+// A SortedSet is safe for concurrent use, because it can not be modified.
+type SortedSet[E cmp.Ordered] struct {
+	s []E
+}
+
+// SortedOf returns a new sorted set with the elements v.
+func SortedOf[E cmp.Ordered](v ...E) SortedSet[E] {
+	return newSortedSet(slices.Clone(v))
+}
+
+// CollectSorted collects values from seq into a new sorted set and returns it.
+func CollectSorted[E cmp.Ordered](seq iter.Seq[E]) SortedSet[E] {
+	return newSortedSet(slices.Collect(seq))
+}
+
+// newSortedSet returns a new sorted set from slice s, which is modified and owned by the set.
+func newSortedSet[E cmp.Ordered](s []E) SortedSet[E] {
+	slices.Sort(s)
+	s = slices.CompactFunc(s, func(a, b E) bool {
+		return cmp.Compare(a, b) == 0
+	})
+	if len(s) == 0 {
+		return SortedSet[E]{}
+	}
+	return SortedSet[E]{s: slices.Clip(s)}
+}
+
+// All returns an iterator over all elements of set s in ascending order.
+func (s SortedSet[E]) All() iter.Seq[E] {
+	return slices.Values(s.s)
+}
+
+// At returns the element at position i of set s in ascending order.
+// It panics if i is out of range.
+func (s SortedSet[E]) At(i int) E {
+	return s.s[i]
+}
+
+// Contains reports whether element v is in set s.
+func (s SortedSet[E]) Contains(v E) bool {
+	_, found := slices.BinarySearch(s.s, v)
+	return found
+}
+
+// Difference returns a new sorted set with the elements of set s, which are not in set other.
+func (s SortedSet[E]) Difference(other SortedSet[E]) SortedSet[E] {
+	var r []E
+	a, b := s.s, other.s
+	for len(a) > 0 && len(b) > 0 {
+		i := gallop(a, b[0])
+		r = append(r, a[:i]...)
+		a = a[i:]
+		if len(a) == 0 {
+			break
+		}
+		j := gallop(b, a[0])
+		b = b[j:]
+		if len(b) > 0 && cmp.Compare(a[0], b[0]) == 0 {
+			a, b = a[1:], b[1:]
+		}
+	}
+	r = append(r, a...)
+	if len(r) == 0 {
+		return SortedSet[E]{}
+	}
+	return SortedSet[E]{s: r}
+}
+
+// Equal reports whether sets s and other contain the same elements.
+func (s SortedSet[E]) Equal(other SortedSet[E]) bool {
+	return slices.EqualFunc(s.s, other.s, func(a, b E) bool {
+		return cmp.Compare(a, b) == 0
+	})
+}
+
+// Intersection returns a new sorted set with the elements, which are in both sets s and other.
+func (s SortedSet[E]) Intersection(other SortedSet[E]) SortedSet[E] {
+	var r []E
+	a, b := s.s, other.s
+	for len(a) > 0 && len(b) > 0 {
+		a = a[gallop(a, b[0]):]
+		if len(a) == 0 {
+			break
+		}
+		b = b[gallop(b, a[0]):]
+		if len(b) > 0 && cmp.Compare(a[0], b[0]) == 0 {
+			r = append(r, a[0])
+			a, b = a[1:], b[1:]
+		}
+	}
+	if len(r) == 0 {
+		return SortedSet[E]{}
+	}
+	return SortedSet[E]{s: r}
+}
+
+// Set returns a new [Set] with the elements of set s.
+func (s SortedSet[E]) Set() Set[E] {
+	return Of(s.s...)
+}
+
+// Size returns the number of elements in set s. An empty set returns 0.
+func (s SortedSet[E]) Size() int {
+	return len(s.s)
+}
+
+// Slice returns a new slice with the elements of set s in ascending order.
+func (s SortedSet[E]) Slice() []E {
+	return slices.Clone(s.s)
+}
+
+// String returns a string representation of set s.
+// Sets are printed with curly brackets in ascending order, e.g. {1 2 10}.
+func (s SortedSet[E]) String() string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, v := range s.s {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprint(&b, v)
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// Union returns a new sorted set with the elements, which are in set s, other or both.
+func (s SortedSet[E]) Union(other SortedSet[E]) SortedSet[E] {
+	a, b := s.s, other.s
+	if len(a) == 0 {
+		return other
+	}
+	if len(b) == 0 {
+		return s
+	}
+	r := make([]E, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		i := gallop(a, b[0])
+		r = append(r, a[:i]...)
+		a = a[i:]
+		if len(a) > 0 && cmp.Compare(a[0], b[0]) == 0 {
+			a = a[1:]
+		}
+		a, b = b, a
+	}
+	r = append(r, a...)
+	r = append(r, b...)
+	return SortedSet[E]{s: slices.Clip(r)}
+}
+
+// gallop returns the position of the first element in sorted slice s, which is not less than v.
+// It searches with exponentially growing steps from the start of s before doing a binary search,
+// so that it takes logarithmic time in the returned position instead of the length of s.
+func gallop[E cmp.Ordered](s []E, v E) int {
+	bound := 1
+	for bound <= len(s) && cmp.Less(s[bound-1], v) {
+		bound *= 2
+	}
+	lo := bound / 2
+	i, _ := slices.BinarySearch(s[lo:min(bound, len(s))], v)
+	return lo + i
+}
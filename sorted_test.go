@@ -0,0 +1,171 @@
+package set_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestSortedSet(t *testing.T) {
+	t.Run("should sort and deduplicate elements", func(t *testing.T) {
+		v := []int{3, 1, 2, 3, 1}
+		s := set.SortedOf(v...)
+		if got, want := s.Slice(), []int{1, 2, 3}; !slices.Equal(got, want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+		if got, want := v, []int{3, 1, 2, 3, 1}; !slices.Equal(got, want) {
+			t.Errorf("got %v, wanted unchanged %v", got, want)
+		}
+		if got := s.Size(); got != 3 {
+			t.Errorf("got %v, wanted 3", got)
+		}
+		if got := s.At(1); got != 2 {
+			t.Errorf("got %v, wanted 2", got)
+		}
+	})
+	t.Run("can collect elements", func(t *testing.T) {
+		s := set.CollectSorted(slices.Values([]string{"b", "a", "b"}))
+		if got, want := slices.Collect(s.All()), []string{"a", "b"}; !slices.Equal(got, want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+	t.Run("should report whether elements are contained", func(t *testing.T) {
+		s := set.SortedOf(1, 3, 5)
+		for _, v := range []int{1, 3, 5} {
+			if !s.Contains(v) {
+				t.Errorf("expected %v to be contained", v)
+			}
+		}
+		for _, v := range []int{0, 2, 6} {
+			if s.Contains(v) {
+				t.Errorf("expected %v to not be contained", v)
+			}
+		}
+	})
+	t.Run("zero value is an empty set", func(t *testing.T) {
+		var s set.SortedSet[int]
+		if s.Size() != 0 || s.Contains(0) || s.String() != "{}" || s.Slice() != nil {
+			t.Errorf("wanted empty set, got %v", s)
+		}
+		if !s.Equal(set.SortedOf[int]()) || !s.Equal(set.CollectSorted(set.Of[int]().All())) {
+			t.Errorf("wanted empty sets to be equal")
+		}
+	})
+	t.Run("should treat NaN as one element", func(t *testing.T) {
+		s := set.SortedOf(1, math.NaN(), math.NaN())
+		if got := s.Size(); got != 2 {
+			t.Errorf("got %v, wanted 2", got)
+		}
+		if !s.Contains(math.NaN()) {
+			t.Errorf("expected NaN to be contained")
+		}
+		if !s.Equal(set.SortedOf(math.NaN(), 1)) {
+			t.Errorf("expected sets with NaN to be equal")
+		}
+		if got := s.Intersection(set.SortedOf(math.NaN())); got.Size() != 1 {
+			t.Errorf("got %v, wanted {NaN}", got)
+		}
+	})
+	t.Run("can convert to set", func(t *testing.T) {
+		got := set.SortedOf(2, 1).Set()
+		want := set.Of(1, 2)
+		if !got.Equal(want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+	t.Run("can print sets in ascending order", func(t *testing.T) {
+		if got, want := set.SortedOf(10, 2, 1).String(), "{1 2 10}"; got != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+}
+
+func TestSortedSet_Equal(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b set.SortedSet[int]
+		want bool
+	}{
+		{"equal", set.SortedOf(1, 2), set.SortedOf(2, 1), true},
+		{"different elements", set.SortedOf(1, 2), set.SortedOf(1, 3), false},
+		{"different sizes", set.SortedOf(1, 2), set.SortedOf(1), false},
+		{"empty", set.SortedOf[int](), set.SortedOf[int](), true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.a.Equal(tc.b); got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSortedSet_Operations(t *testing.T) {
+	cases := []struct {
+		name                 string
+		a, b                 []int
+		union, inter, differ []int
+	}{
+		{"both empty", nil, nil, nil, nil, nil},
+		{"a empty", nil, []int{1}, []int{1}, nil, nil},
+		{"b empty", []int{1}, nil, []int{1}, nil, []int{1}},
+		{"equal", []int{1, 2}, []int{1, 2}, []int{1, 2}, []int{1, 2}, nil},
+		{"disjoint", []int{1, 3}, []int{2, 4}, []int{1, 2, 3, 4}, nil, []int{1, 3}},
+		{"subset", []int{1, 2, 3, 4}, []int{2, 3}, []int{1, 2, 3, 4}, []int{2, 3}, []int{1, 4}},
+		{"superset", []int{2, 3}, []int{1, 2, 3, 4}, []int{1, 2, 3, 4}, []int{2, 3}, nil},
+		{"overlap", []int{1, 2, 5, 6}, []int{2, 3, 6, 7}, []int{1, 2, 3, 5, 6, 7}, []int{2, 6}, []int{1, 5}},
+		{"a before b", []int{1, 2}, []int{3, 4}, []int{1, 2, 3, 4}, nil, []int{1, 2}},
+		{"a after b", []int{3, 4}, []int{1, 2}, []int{1, 2, 3, 4}, nil, []int{3, 4}},
+	}
+	for _, tc := range cases {
+		a, b := set.SortedOf(tc.a...), set.SortedOf(tc.b...)
+		t.Run(tc.name+" union", func(t *testing.T) {
+			if got := a.Union(b).Slice(); !slices.Equal(got, tc.union) {
+				t.Errorf("got %v, wanted %v", got, tc.union)
+			}
+		})
+		t.Run(tc.name+" intersection", func(t *testing.T) {
+			if got := a.Intersection(b).Slice(); !slices.Equal(got, tc.inter) {
+				t.Errorf("got %v, wanted %v", got, tc.inter)
+			}
+		})
+		t.Run(tc.name+" difference", func(t *testing.T) {
+			if got := a.Difference(b).Slice(); !slices.Equal(got, tc.differ) {
+				t.Errorf("got %v, wanted %v", got, tc.differ)
+			}
+		})
+	}
+	t.Run("should match set operations for random sets", func(t *testing.T) {
+		r := rand.New(rand.NewPCG(1, 2))
+		random := func(n, max int) set.Set[int] {
+			var s set.Set[int]
+			for range n {
+				s.Add(r.IntN(max))
+			}
+			return s
+		}
+		for range 100 {
+			x, y := random(r.IntN(200), 500), random(r.IntN(20), 500)
+			a, b := set.CollectSorted(x.All()), set.CollectSorted(y.All())
+			checks := []struct {
+				name string
+				got  set.SortedSet[int]
+				want set.Set[int]
+			}{
+				{"union", a.Union(b), set.Union(x, y)},
+				{"intersection", a.Intersection(b), set.Intersection(x, y)},
+				{"intersection reversed", b.Intersection(a), set.Intersection(x, y)},
+				{"difference", a.Difference(b), set.Difference(x, y)},
+				{"difference reversed", b.Difference(a), set.Difference(y, x)},
+			}
+			for _, c := range checks {
+				if got, want := c.got.Slice(), slices.Sorted(c.want.All()); !slices.Equal(got, want) {
+					t.Fatalf("%s: got %v, wanted %v", c.name, got, want)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,240 @@
+package set
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"slices"
+)
+
+// A SpillSet is a set of ordered elements, which spills elements to temporary files
+// when the number of elements in memory exceeds a limit.
+//
+// This allows deduplicating and combining inputs, which are much larger than the available memory.
+// When the limit is reached, the elements in memory are sorted and written as a run to a new file.
+// Iterating over the set merges the sorted runs with the remaining elements in memory,
+// so that only a single element of each run needs to be kept in memory.
+// Elements are encoded as described for [Set.AppendBinary].
+//
+// Errors from reading the temporary files during iteration stop the iteration
+// and can be checked with [SpillSet.Err] afterwards.
+// Errors of the other sets of set operations are recorded in the receiver,
+// so that checking its error is sufficient.
+// A SpillSet must be closed with [SpillSet.Close] to remove its temporary files.
+// SpillSet is not safe for concurrent use.
+type SpillSet[E cmp.Ordered] struct {
+	dir   string
+	limit int
+	mem   Set[E]
+	runs  []*os.File
+	err   error
+}
+
+// NewSpillSet returns a new empty spill set, which keeps at most limit elements in memory
+// and stores temporary files in directory dir.
+// If dir is the empty string, the default directory for temporary files is used.
+// It panics if limit is less than 1.
+func NewSpillSet[E cmp.Ordered](dir string, limit int) *SpillSet[E] {
+	if limit < 1 {
+		panic("set.NewSpillSet: limit must be positive")
+	}
+	return &SpillSet[E]{dir: dir, limit: limit}
+}
+
+// CollectSpill collects values from seq into a new spill set and returns it.
+// The parameters dir and limit are described for [NewSpillSet].
+func CollectSpill[E cmp.Ordered](seq iter.Seq[E], dir string, limit int) (*SpillSet[E], error) {
+	s := NewSpillSet[E](dir, limit)
+	if err := s.AddSeq(seq); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add adds elements v to set s.
+// It returns an error if spilling elements to a temporary file failed.
+func (s *SpillSet[E]) Add(v ...E) error {
+	return s.AddSeq(slices.Values(v))
+}
+
+// AddSeq adds the values from seq to set s.
+// It returns an error if spilling elements to a temporary file failed.
+func (s *SpillSet[E]) AddSeq(seq iter.Seq[E]) error {
+	for v := range seq {
+		s.mem.Add(v)
+		if s.mem.Size() >= s.limit {
+			if err := s.spill(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// All returns an iterator over all elements of set s in ascending order.
+func (s *SpillSet[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		seqs := []iter.Seq[E]{slices.Values(slices.Sorted(s.mem.All()))}
+		for _, f := range s.runs {
+			seqs = append(seqs, s.readRun(f))
+		}
+		for v := range MergeSorted(seqs...) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Close removes the temporary files of set s and clears it.
+func (s *SpillSet[E]) Close() error {
+	var errs []error
+	for _, f := range s.runs {
+		errs = append(errs, f.Close(), os.Remove(f.Name()))
+	}
+	s.runs = nil
+	s.mem.Clear()
+	return errors.Join(errs...)
+}
+
+// Difference returns an iterator over the elements of set s in ascending order,
+// which are not in any of the others.
+// Errors from reading the others are recorded in s as well.
+func (s *SpillSet[E]) Difference(others ...*SpillSet[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		defer s.collectErrs(others)
+		var seqs []iter.Seq[E]
+		for _, o := range others {
+			seqs = append(seqs, o.All())
+		}
+		next, stop := iter.Pull(MergeSorted(seqs...))
+		defer stop()
+		x, ok := next()
+		for v := range s.All() {
+			for ok && cmp.Less(x, v) {
+				x, ok = next()
+			}
+			if ok && cmp.Compare(x, v) == 0 {
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Err returns the first error, which occurred while iterating over set s,
+// including iterating over the results of [SpillSet.Difference] and [SpillSet.Union].
+func (s *SpillSet[E]) Err() error {
+	return s.err
+}
+
+// Union returns an iterator over the elements of set s and the others in ascending order.
+// Errors from reading the others are recorded in s as well.
+func (s *SpillSet[E]) Union(others ...*SpillSet[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		defer s.collectErrs(others)
+		seqs := []iter.Seq[E]{s.All()}
+		for _, o := range others {
+			seqs = append(seqs, o.All())
+		}
+		for v := range MergeSorted(seqs...) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// collectErrs records the first error of the others in s.
+func (s *SpillSet[E]) collectErrs(others []*SpillSet[E]) {
+	for _, o := range others {
+		if o.err != nil {
+			s.setErr(o.err)
+		}
+	}
+}
+
+// spill writes the elements in memory as sorted run to a new temporary file.
+func (s *SpillSet[E]) spill() error {
+	f, err := os.CreateTemp(s.dir, "set-spill-*")
+	if err != nil {
+		return err
+	}
+	if err := s.writeRun(f); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	s.runs = append(s.runs, f)
+	s.mem.Clear()
+	return nil
+}
+
+// writeRun writes the elements in memory in ascending order to w.
+// Each element is prefixed with the length of its encoding.
+// Write errors are sticky and returned when flushing the buffer.
+func (s *SpillSet[E]) writeRun(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	e := newBinaryEncoder[E]()
+	var b []byte
+	for _, v := range slices.Sorted(s.mem.All()) {
+		var err error
+		b, err = e.append(b[:0], v)
+		if err != nil {
+			return err
+		}
+		bw.Write(binary.AppendUvarint(nil, uint64(len(b))))
+		bw.Write(b)
+	}
+	return bw.Flush()
+}
+
+// readRun returns an iterator over the elements of the run in file f.
+// Read errors are recorded in s.err.
+func (s *SpillSet[E]) readRun(f *os.File) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		d, err := newBinaryDecoder[E]()
+		if err != nil {
+			s.setErr(err)
+			return
+		}
+		br := bufio.NewReader(io.NewSectionReader(f, 0, 1<<63-1))
+		var b []byte
+		for {
+			n, err := binary.ReadUvarint(br)
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				s.setErr(err)
+				return
+			}
+			b = slices.Grow(b[:0], int(n))[:n]
+			if _, err := io.ReadFull(br, b); err != nil {
+				s.setErr(err)
+				return
+			}
+			v, _, err := d.read(b)
+			if err != nil {
+				s.setErr(err)
+				return
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (s *SpillSet[E]) setErr(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
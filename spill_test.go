@@ -0,0 +1,244 @@
+package set_test
+
+import (
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestSpillSet(t *testing.T) {
+	t.Run("should deduplicate elements across runs", func(t *testing.T) {
+		dir := t.TempDir()
+		s := set.NewSpillSet[int](dir, 3)
+		defer s.Close()
+		if err := s.Add(5, 1, 3, 1, 4, 5, 9, 2, 6, 5, 3); err != nil {
+			t.Fatal(err)
+		}
+		got := slices.Collect(s.All())
+		want := []int{1, 2, 3, 4, 5, 6, 9}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+		if err := s.Err(); err != nil {
+			t.Errorf("got %q, wanted no error", err)
+		}
+		files, _ := os.ReadDir(dir)
+		if len(files) == 0 {
+			t.Errorf("wanted elements to be spilled")
+		}
+		if err := s.Close(); err != nil {
+			t.Fatal(err)
+		}
+		files, _ = os.ReadDir(dir)
+		if len(files) != 0 {
+			t.Errorf("got %d files, wanted temporary files to be removed", len(files))
+		}
+		if got := slices.Collect(s.All()); len(got) != 0 {
+			t.Errorf("got %v, wanted closed set to be empty", got)
+		}
+	})
+	t.Run("can collect many elements", func(t *testing.T) {
+		seq := func(yield func(string) bool) {
+			for range 3 {
+				for _, v := range []string{"b", "a", "c", "", "d", "a"} {
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		}
+		s, err := set.CollectSpill(seq, t.TempDir(), 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+		got := slices.Collect(s.All())
+		want := []string{"", "a", "b", "c", "d"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+	t.Run("can spill elements using pointer receivers", func(t *testing.T) {
+		s, err := set.CollectSpill(slices.Values([]pointerCode{"c", "a", "b", "a"}), t.TempDir(), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+		got := slices.Collect(s.All())
+		want := []pointerCode{"a", "b", "c"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+		if err := s.Err(); err != nil {
+			t.Errorf("got %q, wanted no error", err)
+		}
+	})
+	t.Run("can stop iterating", func(t *testing.T) {
+		s, err := set.CollectSpill(set.Range(0, 100, 1).All(), t.TempDir(), 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+		var got []int
+		for v := range s.All() {
+			if v == 3 {
+				break
+			}
+			got = append(got, v)
+		}
+		if want := []int{0, 1, 2}; !slices.Equal(got, want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+	t.Run("should panic when limit is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic when it was expected to")
+			}
+		}()
+		set.NewSpillSet[int]("", 0)
+	})
+}
+
+func TestSpillSet_Union(t *testing.T) {
+	a := newSpillSet(t, 1, 3, 5, 7, 9)
+	b := newSpillSet(t, 2, 3, 4, 5)
+	c := newSpillSet(t)
+	got := slices.Collect(a.Union(b, c))
+	want := []int{1, 2, 3, 4, 5, 7, 9}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
+
+func TestSpillSet_Difference(t *testing.T) {
+	a := newSpillSet(t, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	b := newSpillSet(t, 0, 2, 4, 10)
+	c := newSpillSet(t, 3, 4, 9)
+	cases := []struct {
+		name   string
+		s      *set.SpillSet[int]
+		others []*set.SpillSet[int]
+		want   []int
+	}{
+		{"two others", a, []*set.SpillSet[int]{b, c}, []int{1, 5, 6, 7, 8}},
+		{"one other", b, []*set.SpillSet[int]{a}, []int{0, 10}},
+		{"no others", c, nil, []int{3, 4, 9}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := slices.Collect(tc.s.Difference(tc.others...))
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+	t.Run("can stop iterating", func(t *testing.T) {
+		var got []int
+		for v := range a.Difference(b) {
+			got = append(got, v)
+			break
+		}
+		if want := []int{1}; !slices.Equal(got, want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+}
+
+func TestSpillSet_Errors(t *testing.T) {
+	t.Run("should return error when spill file can not be created", func(t *testing.T) {
+		_, err := set.CollectSpill(set.Range(0, 10, 1).All(), filepath.Join(t.TempDir(), "missing"), 2)
+		if err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+	})
+	t.Run("should return error when elements can not be encoded", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := set.CollectSpill(slices.Values([]errString{"a", "b"}), dir, 1)
+		if err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+		files, _ := os.ReadDir(dir)
+		if len(files) != 0 {
+			t.Errorf("got %d files, wanted temporary files to be removed", len(files))
+		}
+	})
+	t.Run("should record read errors", func(t *testing.T) {
+		cases := []struct {
+			name string
+			data string
+		}{
+			{"invalid length", "\xff"},
+			{"truncated element", "\x05a"},
+			{"invalid element", "\x01\xff"},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				dir := t.TempDir()
+				s, err := set.CollectSpill(set.Range(0, 5, 1).All(), dir, 2)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer s.Close()
+				files, _ := os.ReadDir(dir)
+				if err := os.WriteFile(filepath.Join(dir, files[0].Name()), []byte(tc.data), 0o600); err != nil {
+					t.Fatal(err)
+				}
+				for range s.All() {
+				}
+				if s.Err() == nil {
+					t.Errorf("got %q, wanted error", s.Err())
+				}
+			})
+		}
+	})
+	t.Run("should record read errors of other sets in the receiver", func(t *testing.T) {
+		ops := map[string]func(s, o *set.SpillSet[int]) iter.Seq[int]{
+			"union":      func(s, o *set.SpillSet[int]) iter.Seq[int] { return s.Union(o) },
+			"difference": func(s, o *set.SpillSet[int]) iter.Seq[int] { return s.Difference(o) },
+		}
+		for name, op := range ops {
+			t.Run(name, func(t *testing.T) {
+				s := newSpillSet(t, 1, 2)
+				dir := t.TempDir()
+				o, err := set.CollectSpill(set.Range(0, 5, 1).All(), dir, 2)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer o.Close()
+				files, _ := os.ReadDir(dir)
+				if err := os.WriteFile(filepath.Join(dir, files[0].Name()), []byte("\xff"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+				for range op(s, o) {
+				}
+				if s.Err() == nil {
+					t.Errorf("got %q, wanted error", s.Err())
+				}
+			})
+		}
+	})
+}
+
+func newSpillSet(t *testing.T, v ...int) *set.SpillSet[int] {
+	t.Helper()
+	s := set.NewSpillSet[int](t.TempDir(), 2)
+	t.Cleanup(func() {
+		s.Close()
+	})
+	if err := s.Add(v...); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// errString is a string type, which can not be binary encoded.
+type errString string
+
+func (errString) AppendBinary([]byte) ([]byte, error) {
+	return nil, errTest
+}
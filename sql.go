@@ -0,0 +1,96 @@
+package set
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+// CollectRows scans the single column of all rows into a new set and returns it.
+// Rows are always closed.
+// It returns an error if scanning a row failed or if the query returned an error.
+func CollectRows[E comparable](rows *sql.Rows) (Set[E], error) {
+	return CollectRowsFunc(rows, func(rows *sql.Rows) (E, error) {
+		var v E
+		err := rows.Scan(&v)
+		return v, err
+	})
+}
+
+// CollectRowsFunc calls scan for all rows and collects the returned elements into a new set.
+// Rows are always closed.
+// It returns an error if scan returned an error or if the query returned an error.
+func CollectRowsFunc[E comparable](rows *sql.Rows, scan func(*sql.Rows) (E, error)) (Set[E], error) {
+	defer rows.Close()
+	var s Set[E]
+	for rows.Next() {
+		v, err := scan(rows)
+		if err != nil {
+			return Set[E]{}, err
+		}
+		s.Add(v)
+	}
+	if err := rows.Err(); err != nil {
+		return Set[E]{}, err
+	}
+	return s, nil
+}
+
+// Scan replaces set s with the elements from the JSON encoded database value src.
+// NULL values are scanned into a zero set.
+// The set is not changed when scanning fails.
+//
+// Scan implements the [sql.Scanner] interface and allows using sets as fields of models,
+// e.g. for storing them in JSON columns.
+func (s *Set[E]) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		s.m = nil
+		return nil
+	case []byte:
+		return s.UnmarshalJSON(v)
+	case string:
+		return s.UnmarshalJSON([]byte(v))
+	}
+	return fmt.Errorf("%w: can not scan %T into set", ErrInvalidEncoding, src)
+}
+
+// Value returns the JSON encoding of set s as database value.
+// Zero sets are stored as NULL.
+//
+// Value implements the [driver.Valuer] interface.
+func (s Set[E]) Value() (driver.Value, error) {
+	if s.m == nil {
+		return nil, nil
+	}
+	b, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Value returns the JSON encoding of set s as database value.
+// Zero sets are stored as empty JSON arrays.
+//
+// Value implements the [driver.Valuer] interface.
+func (s NonNull[E]) Value() (driver.Value, error) {
+	b, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan replaces set s with the elements from the JSON encoded database value src.
+// Like for [Strict.UnmarshalJSON] it returns an error if the value contains duplicate elements.
+// Otherwise it works the same as [Set.Scan].
+func (s *Strict[E]) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return s.UnmarshalJSON(v)
+	case string:
+		return s.UnmarshalJSON([]byte(v))
+	}
+	return s.Set.Scan(src)
+}
@@ -0,0 +1,254 @@
+package set_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+// fakeDB returns a database, which returns the values from tables for a query with the name of a table.
+// A nil value in a table makes the query fail at that row.
+func fakeDB(t *testing.T, tables map[string][]driver.Value) *sql.DB {
+	db := sql.OpenDB(fakeConnector{tables})
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return db
+}
+
+type fakeConnector struct {
+	tables map[string][]driver.Value
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return fakeConn(c), nil
+}
+
+func (c fakeConnector) Driver() driver.Driver {
+	return nil
+}
+
+type fakeConn fakeConnector
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{c.tables[query]}, nil
+}
+
+func (fakeConn) Close() error {
+	return nil
+}
+
+func (fakeConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+type fakeStmt struct {
+	values []driver.Value
+}
+
+func (fakeStmt) Close() error {
+	return nil
+}
+
+func (fakeStmt) NumInput() int {
+	return 0
+}
+
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, driver.ErrSkip
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{values: s.values}, nil
+}
+
+type fakeRows struct {
+	values []driver.Value
+}
+
+func (*fakeRows) Columns() []string {
+	return []string{"v"}
+}
+
+func (*fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	v := r.values[0]
+	r.values = r.values[1:]
+	if v == nil {
+		return errTest
+	}
+	dest[0] = v
+	return nil
+}
+
+func TestCollectRows(t *testing.T) {
+	db := fakeDB(t, map[string][]driver.Value{
+		"ids":   {int64(1), int64(2), int64(1)},
+		"names": {"a", "b"},
+		"empty": {},
+		"fail":  {int64(1), nil},
+	})
+	t.Run("can collect rows", func(t *testing.T) {
+		rows, err := db.Query("ids")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := set.CollectRows[int](rows)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := set.Of(1, 2)
+		if !got.Equal(want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+	t.Run("should return zero set for empty results", func(t *testing.T) {
+		rows, err := db.Query("empty")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := set.CollectRows[int](rows)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.IsZero() {
+			t.Errorf("got %v, wanted zero set", got)
+		}
+	})
+	t.Run("should return scan errors", func(t *testing.T) {
+		rows, err := db.Query("names")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = set.CollectRows[int](rows)
+		if err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+	})
+	t.Run("should return query errors", func(t *testing.T) {
+		rows, err := db.Query("fail")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = set.CollectRows[int](rows)
+		if err != errTest {
+			t.Errorf("got %q, wanted %q", err, errTest)
+		}
+	})
+}
+
+func TestCollectRowsFunc(t *testing.T) {
+	db := fakeDB(t, map[string][]driver.Value{
+		"names": {"Ann", "bob", "ANN"},
+	})
+	rows, err := db.Query("names")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := set.CollectRowsFunc(rows, func(rows *sql.Rows) (int, error) {
+		var s string
+		err := rows.Scan(&s)
+		return len(s), err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := set.Of(3)
+	if !got.Equal(want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
+
+func TestSet_Scan(t *testing.T) {
+	cases := []struct {
+		name string
+		src  any
+		want set.Set[int]
+	}{
+		{"bytes", []byte("[1,2]"), set.Of(1, 2)},
+		{"string", "[3]", set.Of(3)},
+		{"empty", "[]", set.Of[int]()},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got set.Set[int]
+			if err := got.Scan(tc.src); err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+	t.Run("should scan NULL into zero set", func(t *testing.T) {
+		s := set.Of(1)
+		if err := s.Scan(nil); err != nil {
+			t.Fatal(err)
+		}
+		if !s.IsZero() {
+			t.Errorf("got %v, wanted zero set", s)
+		}
+	})
+	t.Run("should return error for invalid values", func(t *testing.T) {
+		for _, src := range []any{int64(1), "[1", []byte(`["a"]`), "[1,2] garbage", "[3]]"} {
+			s := set.Of(1)
+			if err := s.Scan(src); err == nil {
+				t.Errorf("%v: got %q, wanted error", src, err)
+			}
+			if want := set.Of(1); !s.Equal(want) {
+				t.Errorf("got %v, wanted unchanged set %v", s, want)
+			}
+		}
+	})
+}
+
+func TestSet_Value(t *testing.T) {
+	t.Run("should return JSON encoding", func(t *testing.T) {
+		v, err := set.Of(1).Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := v, "[1]"; got != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+	t.Run("should return NULL for zero sets", func(t *testing.T) {
+		got, err := set.Set[int]{}.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != nil {
+			t.Errorf("got %q, wanted nil", got)
+		}
+	})
+	t.Run("should return encoding errors", func(t *testing.T) {
+		_, err := set.Of(make(chan int)).Value()
+		if err == nil {
+			t.Errorf("got %q, wanted error", err)
+		}
+	})
+	t.Run("can round trip", func(t *testing.T) {
+		want := set.Of("a", "b")
+		v, err := want.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got set.Set[string]
+		if err := got.Scan(v); err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+}
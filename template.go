@@ -0,0 +1,156 @@
+package set
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// FuncMap returns functions for using sets in text and HTML templates.
+// The result can be passed directly to the Funcs method of
+// [text/template.Template] and [html/template.Template].
+//
+// The following functions are provided:
+//
+//   - sortedList s: returns the elements of set s as sorted slice, e.g. for deterministic ranging
+//   - join s sep: returns the sorted elements of set s as string separated by sep
+//   - contains s v: reports whether set s contains element v
+//   - intersect s u: returns the intersection of the sets s and u
+//
+// Elements are sorted in their natural order when they are numbers or strings
+// and by their string representation otherwise.
+// Elements of different types, e.g. in a Set[any], are grouped by their type names.
+func FuncMap() map[string]any {
+	m := map[string]any{
+		"sortedList": templateSortedList,
+		"join":       templateJoin,
+		"contains":   templateContains,
+		"intersect":  templateIntersect,
+	}
+	return m
+}
+
+// templateSet is implemented by all sets and allows using them without knowing their type.
+type templateSet interface {
+	sortedAny() []any
+	containsAny(v any) bool
+	intersectAny(u any) (any, error)
+}
+
+func toTemplateSet(s any) (templateSet, error) {
+	ts, ok := s.(templateSet)
+	if !ok {
+		return nil, fmt.Errorf("set: expected set, got %T", s)
+	}
+	return ts, nil
+}
+
+func templateSortedList(s any) ([]any, error) {
+	ts, err := toTemplateSet(s)
+	if err != nil {
+		return nil, err
+	}
+	return ts.sortedAny(), nil
+}
+
+func templateJoin(s any, sep string) (string, error) {
+	ts, err := toTemplateSet(s)
+	if err != nil {
+		return "", err
+	}
+	var p []string
+	for _, v := range ts.sortedAny() {
+		p = append(p, fmt.Sprint(v))
+	}
+	return strings.Join(p, sep), nil
+}
+
+func templateContains(s any, v any) (bool, error) {
+	ts, err := toTemplateSet(s)
+	if err != nil {
+		return false, err
+	}
+	return ts.containsAny(v), nil
+}
+
+func templateIntersect(s any, u any) (any, error) {
+	ts, err := toTemplateSet(s)
+	if err != nil {
+		return nil, err
+	}
+	return ts.intersectAny(u)
+}
+
+func (s Set[E]) sortedAny() []any {
+	r := make([]any, 0, len(s.m))
+	for v := range s.m {
+		r = append(r, v)
+	}
+	slices.SortFunc(r, compareAny)
+	return r
+}
+
+func (s Set[E]) containsAny(v any) bool {
+	w, ok := v.(E)
+	if ok {
+		return s.Contains(w)
+	}
+	// allow e.g. untyped integer constants from a template for sets of other integer types
+	rv := reflect.ValueOf(v)
+	t := reflect.TypeFor[E]()
+	if !rv.IsValid() || !isOrderedKind(rv.Kind()) || !isOrderedKind(t.Kind()) || !rv.CanConvert(t) {
+		return false
+	}
+	cv := rv.Convert(t)
+	if !cv.CanConvert(rv.Type()) || cv.Convert(rv.Type()).Interface() != v {
+		return false // conversion would change the value
+	}
+	return s.Contains(cv.Interface().(E))
+}
+
+func (s Set[E]) intersectAny(u any) (any, error) {
+	o, ok := u.(Set[E])
+	if !ok {
+		return nil, fmt.Errorf("set: can not intersect %T with %T", s, u)
+	}
+	return Intersection(s, o), nil
+}
+
+// compareAny compares two values.
+// Numbers and strings of the same kind are compared by value.
+// Values of different kinds are ordered by their type names,
+// all other values by their string representation.
+func compareAny(a, b any) int {
+	x := reflect.ValueOf(a)
+	y := reflect.ValueOf(b)
+	if x.Kind() != y.Kind() {
+		// e.g. elements of a Set[any] with numbers and strings
+		if c := cmp.Compare(fmt.Sprintf("%T", a), fmt.Sprintf("%T", b)); c != 0 {
+			return c
+		}
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+	switch x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(x.Int(), y.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(x.Uint(), y.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(x.Float(), y.Float())
+	case reflect.String:
+		return cmp.Compare(x.String(), y.String())
+	}
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func isOrderedKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
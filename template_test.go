@@ -0,0 +1,84 @@
+package set_test
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestFuncMap(t *testing.T) {
+	type point struct{ X, Y int }
+	cases := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{"sorted list of ints", `{{range sortedList .}}{{.}};{{end}}`, set.Of(10, 2, 1), "1;2;10;"},
+		{"sorted list of uints", `{{range sortedList .}}{{.}};{{end}}`, set.Of[uint](10, 2, 1), "1;2;10;"},
+		{"sorted list of floats", `{{range sortedList .}}{{.}};{{end}}`, set.Of(1.5, -2.0), "-2;1.5;"},
+		{"sorted list of strings", `{{range sortedList .}}{{.}};{{end}}`, set.Of("b", "a"), "a;b;"},
+		{"sorted list of structs", `{{range sortedList .}}{{.X}};{{end}}`, set.Of(point{2, 1}, point{1, 2}), "1;2;"},
+		{"sorted list of empty set", `{{range sortedList .}}{{.}};{{end}}`, set.Set[int]{}, ""},
+		{"sorted list of pointer to set", `{{range sortedList .}}{{.}};{{end}}`, ptr(set.Of(2, 1)), "1;2;"},
+		{"join", `{{join . ", "}}`, set.Of(3, 1, 2), "1, 2, 3"},
+		{"join mixed types", `{{join . ","}}`, set.Of[any](3, "a", 2.5, 1, nil), "<nil>,2.5,1,3,a"},
+		{"contains", `{{contains . 2}} {{contains . 3}}`, set.Of(1, 2), "true false"},
+		{"contains with other integer type", `{{contains . 2}} {{contains . 3}}`, set.Of[int64](1, 2), "true false"},
+		{"contains with lossy conversion", `{{contains . 1.5}}`, set.Of(1), "false"},
+		{"contains with invalid type", `{{contains . "a"}} {{contains . nil}}`, set.Of(1), "false false"},
+		{"contains struct with incompatible type", `{{contains . 1}}`, set.Of(point{}), "false"},
+		{"intersect", `{{intersect .A .B}}`, map[string]set.Set[int]{"A": set.Of(1, 2), "B": set.Of(2, 3)}, "{2}"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := template.Must(template.New("test").Funcs(set.FuncMap()).Parse(tc.tmpl))
+			var b strings.Builder
+			if err := tmpl.Execute(&b, tc.data); err != nil {
+				t.Fatal(err)
+			}
+			if got := b.String(); got != tc.want {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+	t.Run("can use with HTML templates", func(t *testing.T) {
+		tmpl := htmltemplate.Must(htmltemplate.New("test").Funcs(set.FuncMap()).Parse(`{{join . ","}}`))
+		var b strings.Builder
+		if err := tmpl.Execute(&b, set.Of("<a>", "b")); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := b.String(), "&lt;a&gt;,b"; got != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+	t.Run("should return error when arguments are not sets", func(t *testing.T) {
+		cases := []struct {
+			name string
+			tmpl string
+			data any
+		}{
+			{"sorted list", `{{sortedList .}}`, []int{1}},
+			{"join", `{{join . ","}}`, []int{1}},
+			{"contains", `{{contains . 1}}`, []int{1}},
+			{"intersect", `{{intersect . .}}`, []int{1}},
+			{"intersect with different type", `{{intersect .A .B}}`, map[string]any{"A": set.Of(1), "B": set.Of("a")}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				tmpl := template.Must(template.New("test").Funcs(set.FuncMap()).Parse(tc.tmpl))
+				var b strings.Builder
+				if err := tmpl.Execute(&b, tc.data); err == nil {
+					t.Errorf("got %q, wanted error", err)
+				}
+			})
+		}
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
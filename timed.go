@@ -0,0 +1,137 @@
+package set
+
+import (
+	"iter"
+	"maps"
+	"time"
+)
+
+// A Clock provides the current time to the time-based types of this package.
+//
+// This allows testing time-dependent behavior deterministically with a fake clock.
+// A nil Clock means the system clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// clockNow returns the current time of clock c or of the system clock if c is nil.
+func clockNow(c Clock) time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// A TimedSet is a set, which records the time when each element was last added.
+//
+// This allows to query which elements have been added since a point in time
+// and to prune elements by age, e.g. for cache invalidation.
+//
+// The current time is taken from the system clock or from a Clock given to [NewTimedSet].
+//
+// The zero value of a TimedSet is an empty set ready to use.
+// TimedSet is not safe for concurrent use.
+type TimedSet[E comparable] struct {
+	m     map[E]time.Time
+	clock Clock
+}
+
+// NewTimedSet returns a new empty timed set, which takes the current time from clock.
+// If clock is nil, the system clock is used.
+func NewTimedSet[E comparable](clock Clock) *TimedSet[E] {
+	return &TimedSet[E]{clock: clock}
+}
+
+// Add adds elements v to set s with the current time as timestamp.
+// The timestamp of elements, which are already in the set, is updated.
+func (s *TimedSet[E]) Add(v ...E) {
+	s.AddAt(clockNow(s.clock), v...)
+}
+
+// AddAt adds elements v to set s with timestamp t.
+// The timestamp of elements, which are already in the set, is updated.
+func (s *TimedSet[E]) AddAt(t time.Time, v ...E) {
+	if s.m == nil {
+		s.m = make(map[E]time.Time)
+	}
+	for _, x := range v {
+		s.m[x] = t
+	}
+}
+
+// AddedAt returns the timestamp of element v and reports whether v is in set s.
+func (s *TimedSet[E]) AddedAt(v E) (time.Time, bool) {
+	t, ok := s.m[v]
+	return t, ok
+}
+
+// AddedSince returns a new set with the elements of s, which have been added at or after t.
+func (s *TimedSet[E]) AddedSince(t time.Time) Set[E] {
+	var r Set[E]
+	for v, x := range s.m {
+		if !x.Before(t) {
+			r.Add(v)
+		}
+	}
+	return r
+}
+
+// All returns an iterator over all elements of set s and their timestamps.
+//
+// Note that the order of the elements is undefined.
+func (s *TimedSet[E]) All() iter.Seq2[E, time.Time] {
+	return maps.All(s.m)
+}
+
+// Contains reports whether element v is in set s.
+func (s *TimedSet[E]) Contains(v E) bool {
+	_, ok := s.m[v]
+	return ok
+}
+
+// Delete removes elements v from set s.
+// It returns the number of deleted elements.
+func (s *TimedSet[E]) Delete(v ...E) int {
+	ln := len(s.m)
+	for _, x := range v {
+		delete(s.m, x)
+	}
+	return ln - len(s.m)
+}
+
+// OlderThan returns a new set with the elements of s, which have been added before t.
+func (s *TimedSet[E]) OlderThan(t time.Time) Set[E] {
+	var r Set[E]
+	for v, x := range s.m {
+		if x.Before(t) {
+			r.Add(v)
+		}
+	}
+	return r
+}
+
+// Prune removes all elements from set s, which are older than maxAge.
+// It returns the number of removed elements.
+func (s *TimedSet[E]) Prune(maxAge time.Duration) int {
+	return s.PruneBefore(clockNow(s.clock).Add(-maxAge))
+}
+
+// PruneBefore removes all elements from set s, which have been added before t.
+// It returns the number of removed elements.
+func (s *TimedSet[E]) PruneBefore(t time.Time) int {
+	ln := len(s.m)
+	maps.DeleteFunc(s.m, func(_ E, x time.Time) bool {
+		return x.Before(t)
+	})
+	return ln - len(s.m)
+}
+
+// Set returns a new set with all elements of s.
+func (s *TimedSet[E]) Set() Set[E] {
+	return Collect(maps.Keys(s.m))
+}
+
+// Size returns the number of elements in set s.
+func (s *TimedSet[E]) Size() int {
+	return len(s.m)
+}
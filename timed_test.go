@@ -0,0 +1,133 @@
+package set_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+// fakeClock is a clock, which only advances when told to.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.t
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+func TestTimedSet(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(sec int) time.Time {
+		return start.Add(time.Duration(sec) * time.Second)
+	}
+	newSet := func() *set.TimedSet[int] {
+		var s set.TimedSet[int]
+		s.AddAt(at(0), 1)
+		s.AddAt(at(10), 2)
+		s.AddAt(at(20), 3)
+		return &s
+	}
+	t.Run("can query by time", func(t *testing.T) {
+		s := newSet()
+		cases := []struct {
+			name string
+			got  set.Set[int]
+			want set.Set[int]
+		}{
+			{"added since", s.AddedSince(at(10)), set.Of(2, 3)},
+			{"added since after latest", s.AddedSince(at(21)), set.Of[int]()},
+			{"older than", s.OlderThan(at(10)), set.Of(1)},
+			{"older than before first", s.OlderThan(at(0)), set.Of[int]()},
+			{"all elements", s.Set(), set.Of(1, 2, 3)},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				if !tc.got.Equal(tc.want) {
+					t.Errorf("got %q, wanted %q", tc.got, tc.want)
+				}
+			})
+		}
+	})
+	t.Run("should update timestamp when adding existing element", func(t *testing.T) {
+		s := newSet()
+		s.AddAt(at(30), 1)
+		got, ok := s.AddedAt(1)
+		if !ok || !got.Equal(at(30)) {
+			t.Errorf("got %v %v, wanted %v true", got, ok, at(30))
+		}
+		if _, ok := s.AddedAt(4); ok {
+			t.Errorf("got true, wanted false")
+		}
+	})
+	t.Run("can add with current time", func(t *testing.T) {
+		var s set.TimedSet[int]
+		before := time.Now()
+		s.Add(1)
+		got, _ := s.AddedAt(1)
+		if got.Before(before) || got.After(time.Now()) {
+			t.Errorf("got %v, wanted current time", got)
+		}
+	})
+	t.Run("can prune before time", func(t *testing.T) {
+		s := newSet()
+		if got := s.PruneBefore(at(20)); got != 2 {
+			t.Errorf("got %v, wanted 2", got)
+		}
+		if got, want := s.Set(), set.Of(3); !got.Equal(want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+	t.Run("can prune by age", func(t *testing.T) {
+		var s set.TimedSet[int]
+		s.AddAt(time.Now().Add(-time.Hour), 1)
+		s.Add(2)
+		if got := s.Prune(time.Minute); got != 1 {
+			t.Errorf("got %v, wanted 1", got)
+		}
+		if s.Contains(1) || !s.Contains(2) {
+			t.Errorf("wrong elements pruned")
+		}
+	})
+	t.Run("can use clock", func(t *testing.T) {
+		c := &fakeClock{t: start}
+		s := set.NewTimedSet[int](c)
+		s.Add(1)
+		c.Advance(time.Minute)
+		s.Add(2)
+		if got, _ := s.AddedAt(2); !got.Equal(at(60)) {
+			t.Errorf("got %v, wanted %v", got, at(60))
+		}
+		c.Advance(30 * time.Second)
+		if got := s.Prune(time.Minute); got != 1 {
+			t.Errorf("got %v, wanted 1", got)
+		}
+		if s.Contains(1) || !s.Contains(2) {
+			t.Errorf("wrong elements pruned")
+		}
+	})
+	t.Run("can delete and iterate", func(t *testing.T) {
+		s := newSet()
+		if got := s.Delete(1, 4); got != 1 {
+			t.Errorf("got %v, wanted 1", got)
+		}
+		if s.Size() != 2 {
+			t.Errorf("got %v, wanted 2", s.Size())
+		}
+		for v, ts := range s.All() {
+			if want := at((v - 1) * 10); !ts.Equal(want) {
+				t.Errorf("%d: got %v, wanted %v", v, ts, want)
+			}
+		}
+	})
+	t.Run("zero set", func(t *testing.T) {
+		var s set.TimedSet[int]
+		if s.Contains(1) || s.Size() != 0 || s.Delete(1) != 0 || s.PruneBefore(at(0)) != 0 {
+			t.Errorf("zero set should be empty")
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package set
+
+import (
+	"time"
+)
+
+// A Window tracks the distinct elements seen within a sliding window
+// over a stream of elements.
+//
+// The window can be bounded by the number of most recent elements,
+// by the age of the elements relative to the most recent element, or both.
+// Time only advances with the timestamps given to [Window.Add] and [Window.Advance],
+// which makes a Window suitable for processing both live streams and recorded events.
+//
+// Window is not safe for concurrent use.
+type Window[E comparable] struct {
+	maxItems int
+	maxAge   time.Duration
+	items    []windowItem[E]
+	head     int
+	counts   map[E]int
+	latest   time.Time
+}
+
+type windowItem[E comparable] struct {
+	v E
+	t time.Time
+}
+
+// NewWindow returns a new window, which contains at most the last maxItems elements
+// and only elements that are younger than maxAge.
+// A zero value for maxItems or maxAge means that the window is not bounded in that dimension.
+// It panics if any of the bounds is negative.
+func NewWindow[E comparable](maxItems int, maxAge time.Duration) *Window[E] {
+	if maxItems < 0 {
+		panic("set.NewWindow: negative maxItems")
+	}
+	if maxAge < 0 {
+		panic("set.NewWindow: negative maxAge")
+	}
+	w := &Window[E]{
+		maxItems: maxItems,
+		maxAge:   maxAge,
+		counts:   make(map[E]int),
+	}
+	return w
+}
+
+// Add adds element v seen at time t to window w
+// and evicts all elements, which have fallen out of the window.
+// Timestamps are expected to be non-decreasing.
+// A timestamp before the latest timestamp is treated as the latest timestamp.
+func (w *Window[E]) Add(v E, t time.Time) {
+	if t.Before(w.latest) {
+		t = w.latest
+	}
+	w.items = append(w.items, windowItem[E]{v: v, t: t})
+	w.counts[v]++
+	w.Advance(t)
+}
+
+// Advance moves the time of window w forward to t
+// and evicts all elements, which have fallen out of the window.
+// This allows expiring elements when no new elements are added.
+// Times before the latest timestamp are ignored.
+func (w *Window[E]) Advance(t time.Time) {
+	if t.After(w.latest) {
+		w.latest = t
+	}
+	for w.maxItems > 0 && w.len() > w.maxItems {
+		w.evict()
+	}
+	if w.maxAge > 0 {
+		cutoff := w.latest.Add(-w.maxAge)
+		for w.len() > 0 && !w.items[w.head].t.After(cutoff) {
+			w.evict()
+		}
+	}
+}
+
+// AsSet returns a new set with the distinct elements currently in window w.
+func (w *Window[E]) AsSet() Set[E] {
+	var s Set[E]
+	for v := range w.counts {
+		s.Add(v)
+	}
+	return s
+}
+
+// Contains reports whether element v is currently in window w.
+func (w *Window[E]) Contains(v E) bool {
+	_, ok := w.counts[v]
+	return ok
+}
+
+// DistinctCount returns the number of distinct elements currently in window w.
+func (w *Window[E]) DistinctCount() int {
+	return len(w.counts)
+}
+
+func (w *Window[E]) len() int {
+	return len(w.items) - w.head
+}
+
+// evict removes the oldest item from the window.
+func (w *Window[E]) evict() {
+	it := w.items[w.head]
+	w.items[w.head] = windowItem[E]{}
+	w.head++
+	if c := w.counts[it.v]; c > 1 {
+		w.counts[it.v] = c - 1
+	} else {
+		delete(w.counts, it.v)
+	}
+	// reclaim the space of evicted items once they make up half of the buffer
+	if w.head > len(w.items)/2 {
+		n := copy(w.items, w.items[w.head:])
+		clear(w.items[n:])
+		w.items = w.items[:n]
+		w.head = 0
+	}
+}
@@ -0,0 +1,100 @@
+package set_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+func TestWindow(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(sec int) time.Time {
+		return start.Add(time.Duration(sec) * time.Second)
+	}
+	type item struct {
+		v   int
+		sec int
+	}
+	cases := []struct {
+		name     string
+		maxItems int
+		maxAge   time.Duration
+		items    []item
+		want     set.Set[int]
+	}{
+		{"no elements", 3, 0, []item{}, set.Of[int]()},
+		{"bounded by items", 3, 0, []item{{1, 0}, {2, 1}, {3, 2}, {4, 3}}, set.Of(2, 3, 4)},
+		{"bounded by items with duplicates", 3, 0, []item{{1, 0}, {1, 1}, {2, 2}, {1, 3}}, set.Of(1, 2)},
+		{"bounded by age", 0, 10 * time.Second, []item{{1, 0}, {2, 5}, {3, 10}, {4, 12}}, set.Of(2, 3, 4)},
+		{"bounded by age with duplicates", 0, 10 * time.Second, []item{{1, 0}, {2, 5}, {1, 9}, {3, 12}}, set.Of(1, 2, 3)},
+		{"bounded by items and age", 2, 10 * time.Second, []item{{1, 0}, {2, 5}, {3, 6}}, set.Of(2, 3)},
+		{"out of order timestamps", 0, 10 * time.Second, []item{{1, 0}, {2, 12}, {3, 1}}, set.Of(2, 3)},
+		{"unbounded", 0, 0, []item{{1, 0}, {2, 5}, {3, 100}}, set.Of(1, 2, 3)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := set.NewWindow[int](tc.maxItems, tc.maxAge)
+			for _, it := range tc.items {
+				w.Add(it.v, at(it.sec))
+			}
+			got := w.AsSet()
+			if !got.Equal(tc.want) {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+			if w.DistinctCount() != tc.want.Size() {
+				t.Errorf("got %v, wanted %v", w.DistinctCount(), tc.want.Size())
+			}
+			for v := range tc.want.All() {
+				if !w.Contains(v) {
+					t.Errorf("expected %v to be in window", v)
+				}
+			}
+		})
+	}
+	t.Run("can advance time without adding elements", func(t *testing.T) {
+		w := set.NewWindow[int](0, 10*time.Second)
+		w.Add(1, at(0))
+		w.Add(2, at(5))
+		w.Advance(at(12))
+		want := set.Of(2)
+		if got := w.AsSet(); !got.Equal(want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+		w.Advance(at(1))
+		if got := w.AsSet(); !got.Equal(want) {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+		w.Advance(at(20))
+		if got := w.DistinctCount(); got != 0 {
+			t.Errorf("got %v, wanted 0", got)
+		}
+		if w.Contains(2) {
+			t.Errorf("did not expect 2 to be in window")
+		}
+	})
+	t.Run("can handle long streams", func(t *testing.T) {
+		w := set.NewWindow[int](100, 0)
+		for i := range 10_000 {
+			w.Add(i%250, at(i))
+		}
+		if got := w.DistinctCount(); got != 100 {
+			t.Errorf("got %v, wanted 100", got)
+		}
+	})
+	t.Run("should panic when bounds are negative", func(t *testing.T) {
+		for _, f := range []func(){
+			func() { set.NewWindow[int](-1, 0) },
+			func() { set.NewWindow[int](0, -1) },
+		} {
+			func() {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Errorf("The code did not panic when it was expected to")
+					}
+				}()
+				f()
+			}()
+		}
+	})
+}